@@ -0,0 +1,375 @@
+package confluentcloud
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// UploadAttachment uploads a file as an attachment of the given content.
+// The multipart body is streamed from r, so the file is never held in memory.
+// As r can not be rewound the upload is never retried.
+func (a *api) UploadAttachment(ctx context.Context, contentID string, name string, r io.Reader, opts UploadAttachmentOptions) (*Results, error) {
+	if name == "" {
+		return nil, errors.New("file name empty")
+	}
+
+	ep, err := a.getContentChildEndpoint(contentID, "attachment")
+	if err != nil {
+		return nil, err
+	}
+
+	// PUT creates the attachment or adds a new version to the one with the same name
+	method := http.MethodPost
+	if opts.NewVersion {
+		method = http.MethodPut
+	}
+
+	pr, pw := io.Pipe()
+	mw := multipart.NewWriter(pw)
+	go func() {
+		pw.CloseWithError(writeAttachmentForm(mw, name, r, opts))
+	}()
+	// unblocks the writer when the request ends before the body was consumed
+	defer pr.Close()
+
+	req, err := http.NewRequestWithContext(ctx, method, ep.String(), pr)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("X-Atlassian-Token", "nocheck")
+
+	res, err := a.Request(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var content Content
+	err = json.Unmarshal(res, &content)
+	if err != nil {
+		return nil, err
+	}
+	if len(content.Results) == 0 {
+		return nil, errors.New("no attachment returned")
+	}
+	return &content.Results[0], nil
+}
+
+// writeAttachmentForm writes the multipart form of an attachment upload
+func writeAttachmentForm(mw *multipart.Writer, name string, r io.Reader, opts UploadAttachmentOptions) error {
+	if opts.Comment != "" {
+		if err := mw.WriteField("comment", opts.Comment); err != nil {
+			return err
+		}
+	}
+	if err := mw.WriteField("minorEdit", strconv.FormatBool(opts.MinorEdit)); err != nil {
+		return err
+	}
+
+	contentType := opts.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+	h := make(textproto.MIMEHeader)
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(name)))
+	h.Set("Content-Type", contentType)
+	part, err := mw.CreatePart(h)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(part, r); err != nil {
+		return err
+	}
+	return mw.Close()
+}
+
+// DownloadAttachment returns the content of an attachment as a stream the caller must close.
+// The download can be resumed from opts.Offset, in which case the stream starts at that byte.
+// A listed FileAttachment is downloaded with its AsResults.
+func (a *api) DownloadAttachment(ctx context.Context, attachment Results, opts DownloadAttachmentOptions) (io.ReadCloser, error) {
+	if attachment.Links.Download == "" {
+		return nil, errors.New("download link empty")
+	}
+	size := attachment.AsFileAttachment().FileSize
+	if opts.VerifySize && size <= 0 {
+		return nil, fmt.Errorf("%w: extensions.fileSize of %s was not read", ErrSizeUnknown, attachment.ID)
+	}
+	u := attachment.Links.Download
+	if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+		u = a.baseURL() + u
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	if opts.Offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", opts.Offset))
+	}
+
+	resp, err := a.do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		// the server ignored the range, skip the bytes already downloaded
+		if opts.Offset > 0 {
+			if _, err := io.CopyN(ioutil.Discard, resp.Body, opts.Offset); err != nil {
+				resp.Body.Close()
+				return nil, err
+			}
+		}
+	case http.StatusPartialContent:
+	default:
+		res, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		return nil, newAPIError(req, resp, res)
+	}
+
+	if opts.VerifySize {
+		return &sizeVerifier{ReadCloser: resp.Body, read: opts.Offset, expected: size}, nil
+	}
+	return resp.Body, nil
+}
+
+// DownloadAttachmentTo writes the content of an attachment to w and returns the number of bytes written.
+// A checksum set in opts is verified once the whole file was written, it requires a download from offset 0.
+func (a *api) DownloadAttachmentTo(ctx context.Context, attachment Results, w io.Writer, opts DownloadAttachmentOptions) (int64, error) {
+	if opts.SHA256 != "" && opts.Offset > 0 {
+		return 0, errors.New("checksum can not be verified on a resumed download")
+	}
+
+	body, err := a.DownloadAttachment(ctx, attachment, opts)
+	if err != nil {
+		return 0, err
+	}
+	defer body.Close()
+
+	h := sha256.New()
+	if opts.SHA256 != "" {
+		w = io.MultiWriter(w, h)
+	}
+	n, err := io.Copy(w, body)
+	if err != nil {
+		return n, err
+	}
+	if opts.SHA256 != "" {
+		if sum := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(sum, opts.SHA256) {
+			return n, fmt.Errorf("%w: got %s, expected %s", ErrChecksumMismatch, sum, opts.SHA256)
+		}
+	}
+	return n, nil
+}
+
+// sizeVerifier fails the read reaching EOF when the stream size differs from the expected one
+type sizeVerifier struct {
+	io.ReadCloser
+	read     int64
+	expected int64
+}
+
+func (v *sizeVerifier) Read(p []byte) (int, error) {
+	n, err := v.ReadCloser.Read(p)
+	v.read += int64(n)
+	if err == io.EOF && v.read != v.expected {
+		return n, fmt.Errorf("%w: got %d bytes, expected %d", ErrSizeMismatch, v.read, v.expected)
+	}
+	return n, err
+}
+
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// AsFileAttachment returns the attachment properties of an attachment content
+func (r Results) AsFileAttachment() FileAttachment {
+	f := FileAttachment{
+		ID:     r.ID,
+		Title:  r.Title,
+		Status: r.Status,
+		Links:  r.Links,
+	}
+	if r.Extensions != nil {
+		f.MediaType = r.Extensions.MediaType
+		f.FileSize = r.Extensions.FileSize
+		f.Comment = r.Extensions.Comment
+		f.FileID = r.Extensions.FileID
+	}
+	if r.Version != nil {
+		f.Version = *r.Version
+	}
+	if r.Container != nil {
+		f.Container = *r.Container
+	}
+	if r.Metadata.MediaType != "" {
+		f.MediaType = r.Metadata.MediaType
+	}
+	if r.Metadata.Comment != "" {
+		f.Comment = r.Metadata.Comment
+	}
+	return f
+}
+
+// AsResults returns the attachment as a content, e.g. to download a listed attachment
+func (f FileAttachment) AsResults() Results {
+	r := Results{
+		ID:     f.ID,
+		Type:   "attachment",
+		Title:  f.Title,
+		Status: f.Status,
+		Extensions: &Extensions{
+			MediaType: f.MediaType,
+			FileSize:  f.FileSize,
+			Comment:   f.Comment,
+			FileID:    f.FileID,
+		},
+		Links:    f.Links,
+		Metadata: Metadata{MediaType: f.MediaType, Comment: f.Comment},
+	}
+	if f.Version.Number != 0 {
+		version := f.Version
+		r.Version = &version
+	}
+	if f.Container.ID != "" {
+		container := f.Container
+		r.Container = &container
+	}
+	return r
+}
+
+// ListAttachments lists the attachments of the given content, following every page
+func (a *api) ListAttachments(ctx context.Context, contentID string, query AttachmentQuery) ([]FileAttachment, error) {
+	ep, err := a.getContentChildEndpoint(contentID, "attachment")
+	if err != nil {
+		return nil, err
+	}
+	ep.RawQuery = addAttachmentQueryParams(query).Encode()
+
+	results, err := a.newContentPaginator(ctx, ep.String()).All(0)
+	if err != nil {
+		return nil, err
+	}
+	attachments := make([]FileAttachment, 0, len(results))
+	for _, r := range results {
+		attachments = append(attachments, r.AsFileAttachment())
+	}
+	return attachments, nil
+}
+
+// UpdateAttachment updates the title, media type, comment and container of an attachment
+// attached to contentID. Changing the container moves the attachment to another content.
+// att.Version.Number is the version being edited, the current version is fetched when it is not set.
+// An empty title or media type is kept from the current attachment.
+func (a *api) UpdateAttachment(ctx context.Context, contentID string, att FileAttachment) (*FileAttachment, error) {
+	if att.ID == "" {
+		return nil, errors.New("id empty")
+	}
+	if att.Version.Number == 0 || att.Title == "" || att.MediaType == "" {
+		c, err := a.GetContentByID(ctx, att.ID, ContentByIDQuery{Expand: []string{"version", "metadata"}})
+		if err != nil {
+			return nil, err
+		}
+		current := c.AsFileAttachment()
+		if att.Version.Number == 0 {
+			att.Version.Number = current.Version.Number
+		}
+		if att.Title == "" {
+			att.Title = current.Title
+		}
+		if att.MediaType == "" {
+			att.MediaType = current.MediaType
+		}
+	}
+
+	ep, err := a.getContentChildEndpoint(contentID, "attachment/"+att.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := attachmentPayload{
+		ID:      att.ID,
+		Type:    "attachment",
+		Title:   att.Title,
+		Version: versionPayload{Number: att.Version.Number + 1},
+		Metadata: attachmentMetadataPayload{
+			MediaType: att.MediaType,
+			Comment:   att.Comment,
+		},
+	}
+	if att.Container.ID != "" {
+		payload.Container = &containerPayload{ID: string(att.Container.ID), Type: att.Container.Type}
+		if payload.Container.Type == "" {
+			payload.Container.Type = "page"
+		}
+	}
+
+	var updated Results
+	err = a.sendRequest(ctx, ep, http.MethodPut, payload, &updated)
+	if err != nil {
+		return nil, err
+	}
+	f := updated.AsFileAttachment()
+	return &f, nil
+}
+
+// MoveAttachment moves an attachment of contentID to the content targetID
+func (a *api) MoveAttachment(ctx context.Context, contentID string, att FileAttachment, targetID string) (*FileAttachment, error) {
+	att.Container = Container{ID: ID(targetID), Type: att.Container.Type}
+	return a.UpdateAttachment(ctx, contentID, att)
+}
+
+// GetAttachmentVersions lists every version of an attachment, latest first
+func (a *api) GetAttachmentVersions(ctx context.Context, attachmentID string) ([]Version, error) {
+	ep, err := a.getContentGenericEndpoint(attachmentID, "version")
+	if err != nil {
+		return nil, err
+	}
+
+	var versions []Version
+	if err := a.collectPages(ctx, ep.String(), &versions); err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
+
+// DownloadAttachmentVersion returns the content of a previous version of an attachment
+// as a stream the caller must close
+func (a *api) DownloadAttachmentVersion(ctx context.Context, attachmentID string, version int, opts DownloadAttachmentOptions) (io.ReadCloser, error) {
+	att, err := a.GetContentByID(ctx, attachmentID, ContentByIDQuery{Version: version})
+	if err != nil {
+		return nil, err
+	}
+	return a.DownloadAttachment(ctx, *att, opts)
+}
+
+// addAttachmentQueryParams adds the defined query parameters
+func addAttachmentQueryParams(query AttachmentQuery) *url.Values {
+	data := url.Values{}
+	expand := query.Expand
+	if len(expand) == 0 {
+		expand = []string{"version", "container"}
+	}
+	data.Set("expand", strings.Join(expand, ","))
+	if query.Filename != "" {
+		data.Set("filename", query.Filename)
+	}
+	if query.MediaType != "" {
+		data.Set("mediaType", query.MediaType)
+	}
+	if query.Limit != 0 {
+		data.Set("limit", strconv.Itoa(query.Limit))
+	}
+	return &data
+}
@@ -0,0 +1,330 @@
+package confluentcloud
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func uploadServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/content/42/child/attachment" {
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "nocheck", r.Header.Get("X-Atlassian-Token"))
+
+		assert.Nil(t, r.ParseMultipartForm(1<<20))
+		f, h, err := r.FormFile("file")
+		assert.Nil(t, err)
+		b, _ := ioutil.ReadAll(f)
+
+		if r.Method == http.MethodPost && h.Filename == "exists.txt" {
+			http.Error(w, `{"statusCode":400,"message":"Cannot add a new attachment with same file name as an existing attachment: exists.txt"}`, http.StatusBadRequest)
+			return
+		}
+
+		w.Write([]byte(`{"results":[{"id":"att1","type":"attachment","title":"` + h.Filename + `",` +
+			`"metadata":{"mediaType":"` + h.Header.Get("Content-Type") + `","comment":"` + r.FormValue("comment") + `"},` +
+			`"extensions":{"fileSize":` + strconv.Itoa(len(b)) + `},` +
+			`"version":{"number":1,"minorEdit":` + r.FormValue("minorEdit") + `}}],"size":1}`))
+	}))
+}
+
+func Test_UploadAttachment(t *testing.T) {
+	server := uploadServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	a, err := api.UploadAttachment(context.Background(), "42", "notes.txt", strings.NewReader("hello"), UploadAttachmentOptions{
+		Comment:     "first draft",
+		MinorEdit:   true,
+		ContentType: "text/plain",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "att1", a.ID)
+	assert.Equal(t, "notes.txt", a.Title)
+	assert.Equal(t, "text/plain", a.Metadata.MediaType)
+	assert.Equal(t, "first draft", a.Metadata.Comment)
+	assert.Equal(t, int64(5), a.Extensions.FileSize)
+	assert.True(t, a.Version.MinorEdit)
+}
+
+func Test_UploadAttachment_NewVersion(t *testing.T) {
+	server := uploadServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	_, err = api.UploadAttachment(context.Background(), "42", "exists.txt", strings.NewReader("v2"), UploadAttachmentOptions{})
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusBadRequest, apiErr.StatusCode)
+
+	a, err := api.UploadAttachment(context.Background(), "42", "exists.txt", strings.NewReader("v2"), UploadAttachmentOptions{NewVersion: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "application/octet-stream", a.Metadata.MediaType)
+	assert.False(t, a.Version.MinorEdit)
+}
+
+func Test_UploadAttachment_Errors(t *testing.T) {
+	server := uploadServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	_, err = api.UploadAttachment(context.Background(), "42", "", strings.NewReader("x"), UploadAttachmentOptions{})
+	assert.Equal(t, "file name empty", err.Error())
+
+	_, err = api.UploadAttachment(context.Background(), "43", "a.txt", strings.NewReader(strings.Repeat("x", 1<<20)), UploadAttachmentOptions{})
+	assert.NotNil(t, err)
+}
+
+func TestWriteAttachmentForm_EscapesFileName(t *testing.T) {
+	assert.Equal(t, `a\"b\\c`, quoteEscaper.Replace(`a"b\c`))
+}
+
+const downloadContent = "0123456789abcdef"
+
+func downloadServer() *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/download/attachments/42/data.bin":
+			http.ServeContent(w, r, "data.bin", time.Time{}, strings.NewReader(downloadContent))
+		case "/wiki/download/attachments/42/norange.bin":
+			w.Write([]byte(downloadContent))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+}
+
+func attachmentFor(name string) Results {
+	return Results{
+		ID:         "att1",
+		Title:      name,
+		Extensions: &Extensions{FileSize: int64(len(downloadContent))},
+		Links:      Links{Download: "/download/attachments/42/" + name + "?version=1&api=v2"},
+	}
+}
+
+func Test_DownloadAttachment(t *testing.T) {
+	server := downloadServer()
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	for _, name := range []string{"data.bin", "norange.bin"} {
+		body, err := api.DownloadAttachment(ctx, attachmentFor(name), DownloadAttachmentOptions{VerifySize: true})
+		assert.Nil(t, err)
+		b, err := ioutil.ReadAll(body)
+		assert.Nil(t, err)
+		assert.Nil(t, body.Close())
+		assert.Equal(t, downloadContent, string(b))
+
+		body, err = api.DownloadAttachment(ctx, attachmentFor(name), DownloadAttachmentOptions{Offset: 10, VerifySize: true})
+		assert.Nil(t, err)
+		b, err = ioutil.ReadAll(body)
+		assert.Nil(t, err)
+		body.Close()
+		assert.Equal(t, "abcdef", string(b))
+	}
+
+	listed := attachmentFor("data.bin").AsFileAttachment()
+	body, err := api.DownloadAttachment(ctx, listed.AsResults(), DownloadAttachmentOptions{VerifySize: true})
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(body)
+	assert.Nil(t, err)
+	body.Close()
+	assert.Equal(t, downloadContent, string(b))
+
+	_, err = api.DownloadAttachment(ctx, attachmentFor("missing.bin"), DownloadAttachmentOptions{})
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	_, err = api.DownloadAttachment(ctx, Results{}, DownloadAttachmentOptions{})
+	assert.Equal(t, "download link empty", err.Error())
+}
+
+func Test_DownloadAttachment_VerifySize(t *testing.T) {
+	server := downloadServer()
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	a := attachmentFor("data.bin")
+	a.Extensions.FileSize = 20
+	body, err := api.DownloadAttachment(context.Background(), a, DownloadAttachmentOptions{VerifySize: true})
+	assert.Nil(t, err)
+	defer body.Close()
+	_, err = ioutil.ReadAll(body)
+	assert.True(t, errors.Is(err, ErrSizeMismatch))
+	assert.Equal(t, "attachment size mismatch: got 16 bytes, expected 20", err.Error())
+
+	a.Extensions = nil
+	_, err = api.DownloadAttachment(context.Background(), a, DownloadAttachmentOptions{VerifySize: true})
+	assert.True(t, errors.Is(err, ErrSizeUnknown))
+}
+
+func Test_DownloadAttachmentTo(t *testing.T) {
+	server := downloadServer()
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	sum := sha256.Sum256([]byte(downloadContent))
+	var buf bytes.Buffer
+	n, err := api.DownloadAttachmentTo(ctx, attachmentFor("data.bin"), &buf, DownloadAttachmentOptions{SHA256: hex.EncodeToString(sum[:])})
+	assert.Nil(t, err)
+	assert.Equal(t, int64(16), n)
+	assert.Equal(t, downloadContent, buf.String())
+
+	_, err = api.DownloadAttachmentTo(ctx, attachmentFor("data.bin"), ioutil.Discard, DownloadAttachmentOptions{SHA256: "00"})
+	assert.True(t, errors.Is(err, ErrChecksumMismatch))
+
+	_, err = api.DownloadAttachmentTo(ctx, attachmentFor("data.bin"), ioutil.Discard, DownloadAttachmentOptions{SHA256: "00", Offset: 1})
+	assert.NotNil(t, err)
+}
+
+func attachmentMetadataServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/wiki/rest/api/content/42/child/attachment" && r.Method == http.MethodGet:
+			assert.Equal(t, "version,container", r.URL.Query().Get("expand"))
+			if r.URL.Query().Get("start") == "" {
+				w.Write([]byte(`{"results":[{"id":"att1","type":"attachment","title":"a.png","metadata":{"mediaType":"image/png"},"extensions":{"fileSize":10}}],` +
+					`"_links":{"base":"` + server.URL + `/wiki","next":"/rest/api/content/42/child/attachment?expand=version%2Ccontainer&start=1"}}`))
+				return
+			}
+			w.Write([]byte(`{"results":[{"id":"att2","type":"attachment","title":"b.pdf","extensions":{"mediaType":"application/pdf","fileSize":20,"comment":"spec"}}]}`))
+		case r.URL.Path == "/wiki/rest/api/content/att1" && r.Method == http.MethodGet:
+			if r.URL.Query().Get("version") == "1" {
+				w.Write([]byte(`{"id":"att1","type":"attachment","version":{"number":1},"extensions":{"fileSize":3},"_links":{"download":"/download/attachments/42/a.png?version=1"}}`))
+				return
+			}
+			w.Write([]byte(`{"id":"att1","type":"attachment","title":"a.png","version":{"number":2},"metadata":{"mediaType":"image/png"}}`))
+		case r.URL.Path == "/wiki/rest/api/content/42/child/attachment/att1" && r.Method == http.MethodPut:
+			var p attachmentPayload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+			assert.Equal(t, 3, p.Version.Number)
+			container := `{"id":"42","type":"page"}`
+			if p.Container != nil {
+				container = `{"id":"` + p.Container.ID + `","type":"` + p.Container.Type + `"}`
+			}
+			w.Write([]byte(`{"id":"att1","type":"attachment","title":"` + p.Title + `","version":{"number":3},` +
+				`"metadata":{"mediaType":"` + p.Metadata.MediaType + `","comment":"` + p.Metadata.Comment + `"},"container":` + container + `}`))
+		case r.URL.Path == "/wiki/rest/api/content/att1/version":
+			w.Write([]byte(`{"results":[{"number":2,"message":"new logo"},{"number":1}],"size":2}`))
+		case r.URL.Path == "/wiki/download/attachments/42/a.png":
+			assert.Equal(t, "1", r.URL.Query().Get("version"))
+			w.Write([]byte("old"))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func Test_ListAttachments(t *testing.T) {
+	server := attachmentMetadataServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	list, err := api.ListAttachments(context.Background(), "42", AttachmentQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, []FileAttachment{
+		{ID: "att1", Title: "a.png", MediaType: "image/png", FileSize: 10},
+		{ID: "att2", Title: "b.pdf", MediaType: "application/pdf", FileSize: 20, Comment: "spec"},
+	}, list)
+}
+
+func Test_UpdateAttachment(t *testing.T) {
+	server := attachmentMetadataServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	f, err := api.UpdateAttachment(ctx, "42", FileAttachment{ID: "att1", Title: "logo.png", MediaType: "image/png", Comment: "renamed"})
+	assert.Nil(t, err)
+	assert.Equal(t, "logo.png", f.Title)
+	assert.Equal(t, "renamed", f.Comment)
+	assert.Equal(t, 3, f.Version.Number)
+	assert.Equal(t, ID("42"), f.Container.ID)
+
+	f, err = api.UpdateAttachment(ctx, "42", FileAttachment{ID: "att1", Comment: "kept"})
+	assert.Nil(t, err)
+	assert.Equal(t, "a.png", f.Title)
+	assert.Equal(t, "image/png", f.MediaType)
+	assert.Equal(t, "kept", f.Comment)
+
+	f, err = api.MoveAttachment(ctx, "42", FileAttachment{ID: "att1", Title: "logo.png", Version: Version{Number: 2}}, "77")
+	assert.Nil(t, err)
+	assert.Equal(t, Container{ID: "77", Type: "page"}, f.Container)
+
+	_, err = api.UpdateAttachment(ctx, "42", FileAttachment{})
+	assert.Equal(t, "id empty", err.Error())
+}
+
+func Test_AttachmentVersions(t *testing.T) {
+	server := attachmentMetadataServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	versions, err := api.GetAttachmentVersions(ctx, "att1")
+	assert.Nil(t, err)
+	assert.Len(t, versions, 2)
+	assert.Equal(t, "new logo", versions[0].Message)
+
+	body, err := api.DownloadAttachmentVersion(ctx, "att1", 1, DownloadAttachmentOptions{VerifySize: true})
+	assert.Nil(t, err)
+	b, err := ioutil.ReadAll(body)
+	body.Close()
+	assert.Nil(t, err)
+	assert.Equal(t, "old", string(b))
+
+	_, err = api.GetAttachmentVersions(ctx, "att9")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestAsFileAttachment(t *testing.T) {
+	var c Results
+	loadFixture(t, "attachment.json", &c)
+
+	f := c.AsFileAttachment()
+	assert.Equal(t, "architecture.png", f.Title)
+	assert.Equal(t, "image/png", f.MediaType)
+	assert.Equal(t, int64(48213), f.FileSize)
+	assert.Equal(t, "Updated diagram", f.Comment)
+	assert.Equal(t, 2, f.Version.Number)
+	assert.Equal(t, ID("65538"), f.Container.ID)
+	assert.Equal(t, c.Links.Download, f.Links.Download)
+	assert.Equal(t, f, f.AsResults().AsFileAttachment())
+}
@@ -0,0 +1,108 @@
+package confluentcloud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ExpandBody returns the expand values selecting the given body representations,
+// e.g. query.Expand = append(query.Expand, ExpandBody(RepresentationView)...)
+func ExpandBody(representations ...string) []string {
+	expand := make([]string, 0, len(representations))
+	for _, r := range representations {
+		expand = append(expand, "body."+r)
+	}
+	return expand
+}
+
+// ExpandBodyWebresource returns the expand values selecting a body representation
+// together with the web resources needed to display it
+func ExpandBodyWebresource(representation string) []string {
+	prefix := "body." + representation + ".webresource."
+	return []string{
+		prefix + "superbatch.metatags",
+		prefix + "superbatch.tags.all",
+		prefix + "superbatch.uris.all",
+		prefix + "tags.all",
+		prefix + "uris.all",
+	}
+}
+
+// Representation returns the body in the given representation and whether it was returned
+func (b *Body) Representation(representation string) (Storage, bool) {
+	if b == nil {
+		return Storage{}, false
+	}
+	var s *Storage
+	switch representation {
+	case RepresentationStorage:
+		s = b.Storage
+	case RepresentationView:
+		s = b.View
+	case RepresentationExportView:
+		s = b.ExportView
+	case RepresentationStyledView:
+		s = b.StyledView
+	case RepresentationEditor:
+		s = b.Editor
+	case RepresentationEditor2:
+		s = b.Editor2
+	case RepresentationAnonymousExportView:
+		s = b.AnonymousExportView
+	case RepresentationAtlasDocFormat:
+		s = b.AtlasDocFormat
+	case RepresentationWiki:
+		s = b.Wiki
+	case RepresentationDynamic:
+		s = b.Dynamic
+	}
+	if s == nil {
+		return Storage{}, false
+	}
+	return *s, s.Representation != "" || s.Value != ""
+}
+
+// editableRepresentations are the representations content can be saved in
+var editableRepresentations = []string{RepresentationStorage, RepresentationAtlasDocFormat, RepresentationWiki}
+
+// editable returns the body to send back when updating content, in the given representation.
+// Without a representation the body must hold a single editable representation,
+// when it holds several the one the caller edited cannot be told apart and an error is returned.
+func (b *Body) editable(representation string) (ContentBody, bool, error) {
+	if representation != "" {
+		s, ok := b.Representation(representation)
+		if !ok || s.Value == "" {
+			return ContentBody{}, false, fmt.Errorf("body has no %s representation", representation)
+		}
+		return ContentBody{Value: s.Value, Representation: representation}, true, nil
+	}
+
+	var found []ContentBody
+	for _, r := range editableRepresentations {
+		if s, ok := b.Representation(r); ok && s.Value != "" {
+			found = append(found, ContentBody{Value: s.Value, Representation: r})
+		}
+	}
+	switch len(found) {
+	case 0:
+		return ContentBody{}, false, nil
+	case 1:
+		return found[0], true, nil
+	}
+	names := make([]string, 0, len(found))
+	for _, f := range found {
+		names = append(names, f.Representation)
+	}
+	return ContentBody{}, false, fmt.Errorf("body holds several representations (%s), set the one to save", strings.Join(names, ", "))
+}
+
+// RawJSON returns the value of a JSON based representation, such as atlas_doc_format,
+// as raw JSON the caller can unmarshal into its own document model
+func (s Storage) RawJSON() (json.RawMessage, error) {
+	if !json.Valid([]byte(s.Value)) {
+		return nil, errors.New("body value is not valid JSON")
+	}
+	return json.RawMessage(s.Value), nil
+}
@@ -0,0 +1,88 @@
+package confluentcloud
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBody_Fixture(t *testing.T) {
+	var c Results
+	loadFixture(t, "body.json", &c)
+
+	for _, r := range []string{
+		RepresentationStorage,
+		RepresentationView,
+		RepresentationExportView,
+		RepresentationStyledView,
+		RepresentationEditor,
+		RepresentationAnonymousExportView,
+		RepresentationAtlasDocFormat,
+	} {
+		s, ok := c.Body.Representation(r)
+		assert.True(t, ok, r)
+		assert.Equal(t, r, s.Representation)
+	}
+	_, ok := c.Body.Representation(RepresentationWiki)
+	assert.False(t, ok)
+
+	w := c.Body.StyledView.Webresource
+	assert.Equal(t, []string{"main", "atl.general"}, w.Contexts)
+	assert.Len(t, w.Uris.CSS, 1)
+	assert.Contains(t, w.Tags.All, "batch.css")
+	assert.Equal(t, []string{"/wiki/s/superbatch.js"}, w.Superbatch.Uris.JS)
+	assert.Equal(t, `<meta name="ajs-context-path" content="/wiki">`, w.Superbatch.Metatags)
+
+	adf, err := c.Body.AtlasDocFormat.RawJSON()
+	assert.Nil(t, err)
+	var doc struct {
+		Type    string `json:"type"`
+		Version int    `json:"version"`
+	}
+	assert.Nil(t, json.Unmarshal(adf, &doc))
+	assert.Equal(t, "doc", doc.Type)
+	assert.Equal(t, 1, doc.Version)
+
+	_, err = c.Body.Storage.RawJSON()
+	assert.NotNil(t, err)
+
+	assertRoundTrip(t, c)
+}
+
+func TestExpandBody(t *testing.T) {
+	assert.Equal(t, []string{"body.view", "body.atlas_doc_format"}, ExpandBody(RepresentationView, RepresentationAtlasDocFormat))
+	assert.Empty(t, ExpandBody())
+
+	expand := ExpandBodyWebresource(RepresentationStyledView)
+	assert.Contains(t, expand, "body.styled_view.webresource.tags.all")
+	assert.Contains(t, expand, "body.styled_view.webresource.superbatch.metatags")
+
+	query := ContentQuery{Expand: []string{"version"}}
+	query.Expand = append(query.Expand, ExpandBody(RepresentationExportView)...)
+	assert.Equal(t, "version,body.export_view", addContentQueryParams(query).Get("expand"))
+}
+
+func TestBody_Editable(t *testing.T) {
+	_, ok, err := (&Body{View: &Storage{Value: "<p/>"}}).editable("")
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	b, ok, err := (&Body{View: &Storage{Value: "<p/>"}, AtlasDocFormat: &Storage{Value: "{}"}}).editable("")
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ContentBody{Value: "{}", Representation: RepresentationAtlasDocFormat}, b)
+
+	// with several representations the edited one must be named
+	both := &Body{Storage: &Storage{Value: "<p/>"}, AtlasDocFormat: &Storage{Value: "{}"}}
+	_, _, err = both.editable("")
+	assert.Equal(t, "body holds several representations (storage, atlas_doc_format), set the one to save", err.Error())
+
+	b, ok, err = both.editable(RepresentationAtlasDocFormat)
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, ContentBody{Value: "{}", Representation: RepresentationAtlasDocFormat}, b)
+
+	_, _, err = both.editable(RepresentationWiki)
+	assert.Equal(t, "body has no wiki representation", err.Error())
+}
@@ -0,0 +1,213 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Comment locations
+const (
+	CommentLocationFooter   = "footer"
+	CommentLocationInline   = "inline"
+	CommentLocationResolved = "resolved"
+)
+
+// Resolution statuses of an inline comment
+const (
+	ResolutionOpen     = "open"
+	ResolutionReopened = "reopened"
+	ResolutionResolved = "resolved"
+	ResolutionDangling = "dangling" // the commented text was removed from the page
+)
+
+// commentExpand is expanded when reading comments
+var commentExpand = []string{
+	"ancestors",
+	"body.storage",
+	"version",
+	"container",
+	"extensions.inlineProperties",
+	"extensions.resolution",
+}
+
+// GetComments gets all the comments of a content, replies included, threaded:
+// the top level comments are returned with their replies in the order Confluence lists them.
+// A reply whose parent was filtered out by query.Location is returned as a top level comment.
+func (a *api) GetComments(ctx context.Context, id string, query CommentQuery) ([]*CommentNode, error) {
+	ep, err := a.getContentChildEndpoint(id, "comment")
+	if err != nil {
+		return nil, err
+	}
+	ep.RawQuery = addCommentQueryParams(query).Encode()
+
+	comments, err := a.newContentPaginator(ctx, ep.String()).All(0)
+	if err != nil {
+		return nil, err
+	}
+	return threadComments(comments), nil
+}
+
+// threadComments links every comment to its parent, the last of its ancestors
+func threadComments(comments []Results) []*CommentNode {
+	nodes := make(map[string]*CommentNode, len(comments))
+	for _, c := range comments {
+		nodes[c.ID] = &CommentNode{Comment: c}
+	}
+
+	var threads []*CommentNode
+	for _, c := range comments {
+		node := nodes[c.ID]
+		if n := len(c.Ancestors); n != 0 {
+			if parent, ok := nodes[c.Ancestors[n-1].ID]; ok {
+				node.Parent = parent
+				parent.Replies = append(parent.Replies, node)
+				continue
+			}
+		}
+		threads = append(threads, node)
+	}
+	return threads
+}
+
+// CreateComment creates a comment on a page or blog post, or a reply to another comment
+func (a *api) CreateComment(ctx context.Context, id string, c CreateCommentRequest) (*Results, error) {
+	if c.Body.Value == "" {
+		return nil, errors.New("body empty")
+	}
+
+	ep, err := a.getContentEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := contentPayload{
+		Type:      "comment",
+		Container: &containerPayload{ID: id, Type: c.ContainerType},
+		Body:      bodyPayload(c.Body),
+	}
+	if payload.Container.Type == "" {
+		payload.Container.Type = "page"
+	}
+	if c.ParentID != "" {
+		payload.Ancestors = []idPayload{{ID: c.ParentID}}
+	}
+	if c.Inline != nil {
+		payload.Extensions = &commentExtensionsPayload{Location: CommentLocationInline, InlineProperties: c.Inline}
+	}
+
+	var content Results
+	err = a.sendRequest(ctx, ep, http.MethodPost, payload, &content)
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// UpdateComment replaces the body of a comment
+func (a *api) UpdateComment(ctx context.Context, id string, body ContentBody) (*Results, error) {
+	return a.updateComment(ctx, id, func(p *contentPayload) {
+		p.Body = bodyPayload(body)
+	})
+}
+
+// DeleteComment deletes a comment
+func (a *api) DeleteComment(ctx context.Context, id string) error {
+	ep, err := a.getContentIDEndpoint(id)
+	if err != nil {
+		return err
+	}
+	return a.sendRequest(ctx, ep, http.MethodDelete, nil, nil)
+}
+
+// SetCommentResolution resolves or reopens an inline comment
+func (a *api) SetCommentResolution(ctx context.Context, id string, status string) (*Results, error) {
+	return a.updateComment(ctx, id, func(p *contentPayload) {
+		p.Extensions.Resolution = &resolutionPayload{Status: status}
+	})
+}
+
+// SetInlineSelection moves an inline comment to another selection of its page text
+func (a *api) SetInlineSelection(ctx context.Context, id string, selection InlineProperties) (*Results, error) {
+	return a.updateComment(ctx, id, func(p *contentPayload) {
+		p.Extensions.InlineProperties = &selection
+	})
+}
+
+// updateComment reads a comment, applies fn to a payload saving it unchanged and saves it as the next version
+func (a *api) updateComment(ctx context.Context, id string, fn func(*contentPayload)) (*Results, error) {
+	current, err := a.GetContentByID(ctx, id, ContentByIDQuery{Expand: commentExpand})
+	if err != nil {
+		return nil, err
+	}
+
+	ep, err := a.getContentIDEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := contentPayload{
+		ID:         id,
+		Type:       "comment",
+		Version:    &versionPayload{Number: current.versionNumber() + 1},
+		Extensions: &commentExtensionsPayload{},
+	}
+	if current.Container != nil {
+		payload.Container = &containerPayload{ID: string(current.Container.ID), Type: current.Container.Type}
+	}
+	if current.Extensions != nil {
+		payload.Extensions.Location = current.Extensions.Location
+	}
+	body, ok, err := current.Body.editable("")
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		payload.Body = bodyPayload(body)
+	}
+	fn(&payload)
+
+	var content Results
+	err = a.sendRequest(ctx, ep, http.MethodPut, payload, &content)
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// IsInline reports whether the comment is an inline comment, resolved or not
+func (n *CommentNode) IsInline() bool {
+	if n.Comment.Extensions == nil {
+		return false
+	}
+	switch n.Comment.Extensions.Location {
+	case CommentLocationInline, CommentLocationResolved:
+		return true
+	}
+	return n.Comment.Extensions.InlineProperties != nil
+}
+
+// Walk calls fn for n and all its replies, parents before their replies
+func (n *CommentNode) Walk(fn func(*CommentNode)) {
+	fn(n)
+	for _, r := range n.Replies {
+		r.Walk(fn)
+	}
+}
+
+// addCommentQueryParams adds the defined query parameters
+func addCommentQueryParams(query CommentQuery) *url.Values {
+	data := url.Values{}
+	data.Set("depth", "all")
+	data.Set("expand", strings.Join(append(append([]string{}, commentExpand...), query.Expand...), ","))
+	for _, location := range query.Location {
+		data.Add("location", location)
+	}
+	if query.ParentVersion != 0 {
+		data.Set("parentVersion", strconv.Itoa(query.ParentVersion))
+	}
+	return &data
+}
@@ -0,0 +1,187 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_GetComments(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/wiki/rest/api/content/7/child/comment", r.URL.Path)
+		q := r.URL.Query()
+		assert.Equal(t, "all", q.Get("depth"))
+		assert.Equal(t, []string{"footer", "inline"}, q["location"])
+		assert.Equal(t, "ancestors,body.storage,version,container,extensions.inlineProperties,extensions.resolution,history", q.Get("expand"))
+		w.Write([]byte(`{"results":[
+			{"id":"1","type":"comment","title":"Re: Page","extensions":{"location":"footer"}},
+			{"id":"4","type":"comment","extensions":{"location":"inline",
+				"inlineProperties":{"markerRef":"m-1","originalSelection":"the selected text"},
+				"resolution":{"status":"open","lastModifiedDate":"2026-10-01T10:00:00.000Z"}}},
+			{"id":"2","type":"comment","ancestors":[{"id":"1","type":"comment"}],"extensions":{"location":"footer"}},
+			{"id":"3","type":"comment","ancestors":[{"id":"1","type":"comment"},{"id":"2","type":"comment"}],"extensions":{"location":"footer"}},
+			{"id":"5","type":"comment","ancestors":[{"id":"99","type":"comment"}],"extensions":{"location":"footer"}}
+		]}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	threads, err := api.GetComments(context.Background(), "7", CommentQuery{
+		Location: []string{CommentLocationFooter, CommentLocationInline},
+		Expand:   []string{"history"},
+	})
+	assert.Nil(t, err)
+	assert.Len(t, threads, 3)
+
+	footer := threads[0]
+	assert.Equal(t, "1", footer.Comment.ID)
+	assert.False(t, footer.IsInline())
+	assert.Len(t, footer.Replies, 1)
+	reply := footer.Replies[0]
+	assert.Equal(t, "2", reply.Comment.ID)
+	assert.Equal(t, footer, reply.Parent)
+	assert.Equal(t, "3", reply.Replies[0].Comment.ID)
+
+	var walked []string
+	footer.Walk(func(n *CommentNode) { walked = append(walked, n.Comment.ID) })
+	assert.Equal(t, []string{"1", "2", "3"}, walked)
+
+	inline := threads[1]
+	assert.True(t, inline.IsInline())
+	assert.Equal(t, "the selected text", inline.Comment.Extensions.InlineProperties.OriginalSelection)
+	assert.Equal(t, "m-1", inline.Comment.Extensions.InlineProperties.MarkerRef)
+	assert.Equal(t, ResolutionOpen, inline.Comment.Extensions.Resolution.Status)
+
+	// the parent of 5 was not listed
+	assert.Equal(t, "5", threads[2].Comment.ID)
+	assert.Nil(t, threads[2].Parent)
+}
+
+func Test_CreateComment(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/wiki/rest/api/content/", r.URL.Path)
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.Write([]byte(`{"id":"10","type":"comment"}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	c, err := api.CreateComment(ctx, "7", CreateCommentRequest{Body: ContentBody{Value: "<p>LGTM</p>"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "10", c.ID)
+	assert.JSONEq(t, `{"type":"comment","container":{"id":"7","type":"page"},
+		"body":{"storage":{"value":"<p>LGTM</p>","representation":"storage"}}}`, body)
+
+	_, err = api.CreateComment(ctx, "7", CreateCommentRequest{
+		Body:          ContentBody{Value: "<p>Agreed</p>"},
+		ContainerType: "blogpost",
+		ParentID:      "1",
+	})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"type":"comment","container":{"id":"7","type":"blogpost"},"ancestors":[{"id":"1"}],
+		"body":{"storage":{"value":"<p>Agreed</p>","representation":"storage"}}}`, body)
+
+	_, err = api.CreateComment(ctx, "7", CreateCommentRequest{
+		Body:   ContentBody{Value: "<p>Typo</p>"},
+		Inline: &InlineProperties{OriginalSelection: "teh", MatchIndex: 1, NumMatches: 2},
+	})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"type":"comment","container":{"id":"7","type":"page"},
+		"body":{"storage":{"value":"<p>Typo</p>","representation":"storage"}},
+		"extensions":{"location":"inline","inlineProperties":{"originalSelection":"teh","matchIndex":1,"numMatches":2}}}`, body)
+
+	// the first occurrence of the selection is sent explicitly
+	_, err = api.CreateComment(ctx, "7", CreateCommentRequest{
+		Body:   ContentBody{Value: "<p>Nit</p>"},
+		Inline: &InlineProperties{OriginalSelection: "abc", NumMatches: 1},
+	})
+	assert.Nil(t, err)
+	var payload struct {
+		Extensions struct {
+			InlineProperties map[string]interface{} `json:"inlineProperties"`
+		} `json:"extensions"`
+	}
+	assert.Nil(t, json.Unmarshal([]byte(body), &payload))
+	assert.Contains(t, payload.Extensions.InlineProperties, "matchIndex")
+	assert.Equal(t, float64(0), payload.Extensions.InlineProperties["matchIndex"])
+
+	_, err = api.CreateComment(ctx, "7", CreateCommentRequest{})
+	assert.Equal(t, "body empty", err.Error())
+}
+
+func Test_UpdateComment(t *testing.T) {
+	var body string
+	var deleted bool
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			assert.Equal(t, "/wiki/rest/api/content/4", r.URL.Path)
+			w.Write([]byte(`{"id":"4","type":"comment","version":{"number":2},
+				"container":{"id":"7","type":"page"},
+				"body":{"storage":{"value":"<p>Typo</p>","representation":"storage"}},
+				"extensions":{"location":"inline","inlineProperties":{"markerRef":"m-1"},"resolution":{"status":"open"}}}`))
+		case http.MethodPut:
+			b, _ := ioutil.ReadAll(r.Body)
+			body = string(b)
+			w.Write([]byte(`{"id":"4","type":"comment","version":{"number":3}}`))
+		case http.MethodDelete:
+			if r.URL.Path == "/wiki/rest/api/content/404" {
+				http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+				return
+			}
+			deleted = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	c, err := api.UpdateComment(ctx, "4", ContentBody{Value: "<p>Typo fixed</p>"})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, c.Version.Number)
+	assert.JSONEq(t, `{"id":"4","type":"comment","container":{"id":"7","type":"page"},"version":{"number":3},
+		"body":{"storage":{"value":"<p>Typo fixed</p>","representation":"storage"}},
+		"extensions":{"location":"inline"}}`, body)
+
+	_, err = api.SetCommentResolution(ctx, "4", ResolutionResolved)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id":"4","type":"comment","container":{"id":"7","type":"page"},"version":{"number":3},
+		"body":{"storage":{"value":"<p>Typo</p>","representation":"storage"}},
+		"extensions":{"location":"inline","resolution":{"status":"resolved"}}}`, body)
+
+	_, err = api.SetInlineSelection(ctx, "4", InlineProperties{OriginalSelection: "the", NumMatches: 3})
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{"id":"4","type":"comment","container":{"id":"7","type":"page"},"version":{"number":3},
+		"body":{"storage":{"value":"<p>Typo</p>","representation":"storage"}},
+		"extensions":{"location":"inline","inlineProperties":{"originalSelection":"the","matchIndex":0,"numMatches":3}}}`, body)
+
+	assert.Nil(t, api.DeleteComment(ctx, "4"))
+	assert.True(t, deleted)
+	assert.True(t, errors.Is(api.DeleteComment(ctx, "404"), ErrNotFound))
+}
+
+func TestAddCommentQueryParams(t *testing.T) {
+	p := addCommentQueryParams(CommentQuery{ParentVersion: 4})
+
+	assert.Equal(t, "all", p.Get("depth"))
+	assert.Equal(t, "ancestors,body.storage,version,container,extensions.inlineProperties,extensions.resolution", p.Get("expand"))
+	assert.Equal(t, "4", p.Get("parentVersion"))
+	assert.Nil(t, (*p)["location"])
+}
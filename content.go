@@ -1,7 +1,9 @@
 package confluentcloud
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -10,6 +12,11 @@ import (
 
 // GetContent queries content using ContentQuery
 func (a *api) GetContent(query ContentQuery) (*Content, error) {
+	return a.GetContentWithContext(context.Background(), query)
+}
+
+// GetContentWithContext queries content using ContentQuery and the given context
+func (a *api) GetContentWithContext(ctx context.Context, query ContentQuery) (*Content, error) {
 
 	ep, err := a.getContentEndpoint()
 	if err != nil {
@@ -17,7 +24,7 @@ func (a *api) GetContent(query ContentQuery) (*Content, error) {
 	}
 	ep.RawQuery = addContentQueryParams(query).Encode()
 
-	req, err := http.NewRequest(http.MethodGet, ep.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -37,12 +44,17 @@ func (a *api) GetContent(query ContentQuery) (*Content, error) {
 
 // GetContentFromNext queries content using Links previously retrieved
 func (a *api) GetContentFromNext(links Links) (*Content, error) {
+	return a.GetContentFromNextWithContext(context.Background(), links)
+}
+
+// GetContentFromNextWithContext queries content using Links previously retrieved and the given context
+func (a *api) GetContentFromNextWithContext(ctx context.Context, links Links) (*Content, error) {
 
 	if links.Base == "" || links.Next == "" {
 		return nil, nil
 	}
 	nextUrl := links.Base + links.Next
-	req, err := http.NewRequest(http.MethodGet, nextUrl, nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, nextUrl, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -61,35 +73,198 @@ func (a *api) GetContentFromNext(links Links) (*Content, error) {
 
 // GetAttachmentsFromResult gets all attachments for a given result
 func (a *api) GetAttachmentsFromResult(result Results, baseURL string) ([]Results, error) {
+	return a.GetAttachmentsFromResultWithContext(context.Background(), result, baseURL)
+}
 
-	next := result.Children.Attachment.Links.Next
-	results := result.Children.Attachment.Results
+// GetAttachmentsFromResultWithContext gets all attachments for a given result.
+// The context is checked before every page is requested.
+func (a *api) GetAttachmentsFromResultWithContext(ctx context.Context, result Results, baseURL string) ([]Results, error) {
+	links := Links{Base: baseURL, Next: result.Children.Attachment.Links.Next}
+	next, err := a.PaginateLinks(ctx, links).All(0)
+	if err != nil {
+		return nil, err
+	}
+	return append(result.Children.Attachment.Results, next...), nil
+}
 
-	for {
-		if next == "" {
-			break
-		}
-		rawQuery := baseURL + next
-		req, err := http.NewRequest(http.MethodGet, rawQuery, nil)
-		if err != nil {
-			return nil, err
-		}
+// GetContentByID gets a single content by its id
+func (a *api) GetContentByID(ctx context.Context, id string, query ContentByIDQuery) (*Results, error) {
+	ep, err := a.getContentIDEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+	ep.RawQuery = addContentByIDQueryParams(query).Encode()
+
+	var content Results
+	err = a.sendRequest(ctx, ep, http.MethodGet, nil, &content)
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
 
-		res, err := a.Request(req)
+// CreateContent creates a page or blog post
+func (a *api) CreateContent(ctx context.Context, c CreateContentRequest) (*Results, error) {
+	if c.Title == "" {
+		return nil, errors.New("title empty")
+	}
+	if c.SpaceKey == "" {
+		return nil, errors.New("space key empty")
+	}
+
+	ep, err := a.getContentEndpoint()
+	if err != nil {
+		return nil, err
+	}
+
+	payload := contentPayload{
+		Type:   c.Type,
+		Title:  c.Title,
+		Status: c.Status,
+		Space:  &spacePayload{Key: c.SpaceKey},
+		Body:   bodyPayload(c.Body),
+	}
+	if payload.Type == "" {
+		payload.Type = "page"
+	}
+	if c.ParentID != "" {
+		payload.Ancestors = []idPayload{{ID: c.ParentID}}
+	}
+
+	var content Results
+	err = a.sendRequest(ctx, ep, http.MethodPost, payload, &content)
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// UpdateContent updates c, a content previously read or built by the caller.
+// c.Version.Number is the version being edited, the current version is fetched when it is not set.
+// The content is saved as the next version. The body is sent in opts.Representation,
+// which must be set when c.Body holds several representations. When c.Ancestors or c.Space are set
+// the page is moved under the last ancestor or into the space.
+func (a *api) UpdateContent(ctx context.Context, c Results, opts UpdateContentOptions) (*Results, error) {
+	if c.ID == "" {
+		return nil, errors.New("id empty")
+	}
+
+	version := c.versionNumber()
+	if version == 0 || c.Title == "" || c.Type == "" {
+		current, err := a.GetContentByID(ctx, c.ID, ContentByIDQuery{
+			Expand: []string{"version"},
+			Status: []string{"current", "draft"},
+		})
 		if err != nil {
 			return nil, err
 		}
+		if version == 0 {
+			version = current.versionNumber()
+		}
+		if c.Title == "" {
+			c.Title = current.Title
+		}
+		if c.Type == "" {
+			c.Type = current.Type
+		}
+	}
 
-		var content Content
-		err = json.Unmarshal(res, &content)
+	ep, err := a.getContentIDEndpoint(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := contentPayload{
+		ID:     c.ID,
+		Type:   c.Type,
+		Title:  c.Title,
+		Status: c.Status,
+		Version: &versionPayload{
+			Number:    version + 1,
+			Message:   opts.VersionMessage,
+			MinorEdit: opts.MinorEdit,
+		},
+	}
+	body, ok, err := c.Body.editable(opts.Representation)
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		payload.Body = bodyPayload(body)
+	}
+	// the direct parent is the last ancestor, setting it moves the page
+	if n := len(c.Ancestors); n != 0 {
+		payload.Ancestors = []idPayload{{ID: c.Ancestors[n-1].ID}}
+	}
+	if key := c.spaceKey(); key != "" {
+		payload.Space = &spacePayload{Key: key}
+	}
+
+	var content Results
+	err = a.sendRequest(ctx, ep, http.MethodPut, payload, &content)
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// UpdateContentFunc reads the content with the given id, applies fn to it and saves it as the next version.
+// When the update conflicts with a concurrent one the content is read and fn applied again,
+// up to opts.ConflictRetries times.
+func (a *api) UpdateContentFunc(ctx context.Context, id string, fn func(*Results) error, opts UpdateContentFuncOptions) (*Results, error) {
+	representation := opts.Representation
+	if representation == "" {
+		representation = RepresentationStorage
+	}
+	var updated *Results
+	err := retryOnConflict(ctx, opts.ConflictRetries, func() error {
+		current, err := a.GetContentByID(ctx, id, ContentByIDQuery{
+			Expand: append(ExpandBody(representation), "version"),
+			Status: []string{"current", "draft"},
+		})
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err := fn(current); err != nil {
+			return err
 		}
-		results = append(results, content.Results...)
-		next = content.Links.Next
+		updated, err = a.UpdateContent(ctx, *current, opts.UpdateContentOptions)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
+
+// bodyPayload keys the body by its representation, storage is used when none is set
+func bodyPayload(b ContentBody) map[string]ContentBody {
+	if b.Representation == "" {
+		b.Representation = RepresentationStorage
 	}
+	return map[string]ContentBody{b.Representation: b}
+}
 
-	return results, nil
+// addContentByIDQueryParams adds the defined query parameters
+func addContentByIDQueryParams(query ContentByIDQuery) *url.Values {
+
+	data := url.Values{}
+	if len(query.Expand) != 0 {
+		data.Set("expand", strings.Join(query.Expand, ","))
+	}
+	for _, status := range query.Status {
+		data.Add("status", status)
+	}
+	if query.Version != 0 {
+		data.Set("version", strconv.Itoa(query.Version))
+	}
+	if query.EmbeddedContentRender != "" {
+		data.Set("embeddedContentRender", query.EmbeddedContentRender)
+	}
+	if query.Trigger != "" {
+		data.Set("trigger", query.Trigger)
+	}
+	return &data
 }
 
 // addContentQueryParams adds the defined query parameters
@@ -152,3 +327,19 @@ func (a *api) getContentChildEndpoint(id string, t string) (*url.URL, error) {
 func (a *api) getContentGenericEndpoint(id string, t string) (*url.URL, error) {
 	return url.ParseRequestURI(a.endPoint.String() + "/content/" + id + "/" + t)
 }
+
+// versionNumber returns the number of the content version, 0 when the version was not read
+func (r Results) versionNumber() int {
+	if r.Version == nil {
+		return 0
+	}
+	return r.Version.Number
+}
+
+// spaceKey returns the key of the content space, empty when the space was not read
+func (r Results) spaceKey() string {
+	if r.Space == nil {
+		return ""
+	}
+	return r.Space.Key
+}
@@ -1,8 +1,12 @@
 package confluentcloud
 
 import (
+	"context"
 	"crypto/tls"
+	"encoding/json"
+	"errors"
 	"net/http"
+	"net/http/httptest"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -123,6 +127,43 @@ func TestAttachmentGetter(t *testing.T) {
 	assert.Equal(t, 3, len(r))
 }
 
+func Test_GetContentWithContext_Cancelled(t *testing.T) {
+	server := confluenceRestAPIStub()
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	s, err := api.GetContentWithContext(ctx, ContentQuery{})
+	assert.Nil(t, s)
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	s, err = api.GetContentFromNextWithContext(ctx, Links{Base: server.URL + "/wiki", Next: "/rest/api/content?limit=25&start=0"})
+	assert.Nil(t, s)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
+func TestAttachmentGetterWithContext_CancelledBetweenPages(t *testing.T) {
+	server := confluenceRestAPIStub()
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	s, err := api.GetContent(ContentQuery{})
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	r, err := api.GetAttachmentsFromResultWithContext(ctx, s.Results[0], s.Links.Base)
+	assert.Nil(t, r)
+	assert.Equal(t, context.Canceled, err)
+}
+
 func TestAddContentQueryParams(t *testing.T) {
 	query := ContentQuery{
 		Expand:     []string{"foo", "bar"},
@@ -150,3 +191,219 @@ func TestAddContentQueryParams(t *testing.T) {
 	assert.Equal(t, p.Get("trigger"), "test")
 	assert.Equal(t, p.Get("type"), "test")
 }
+
+func Test_GetContentByID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/wiki/rest/api/content/42" {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "body.storage,version", r.URL.Query().Get("expand"))
+		assert.Equal(t, []string{"current", "draft"}, r.URL.Query()["status"])
+		w.Write([]byte(`{"id":"42","type":"page","status":"draft","title":"Draft","body":{"storage":{"value":"<p>x</p>","representation":"storage"}}}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	c, err := api.GetContentByID(context.Background(), "42", ContentByIDQuery{
+		Expand: []string{"body.storage", "version"},
+		Status: []string{"current", "draft"},
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "42", c.ID)
+	assert.Equal(t, "draft", c.Status)
+	assert.Equal(t, "<p>x</p>", c.Body.Storage.Value)
+
+	c, err = api.GetContentByID(context.Background(), "43", ContentByIDQuery{})
+	assert.Nil(t, c)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestAddContentByIDQueryParams(t *testing.T) {
+	query := ContentByIDQuery{
+		Expand:                []string{"foo", "bar"},
+		Status:                []string{"trashed"},
+		Version:               2,
+		EmbeddedContentRender: "version-at-save",
+		Trigger:               "viewed",
+	}
+
+	p := addContentByIDQueryParams(query)
+
+	assert.Equal(t, p.Get("expand"), "foo,bar")
+	assert.Equal(t, p.Get("status"), "trashed")
+	assert.Equal(t, p.Get("version"), "2")
+	assert.Equal(t, p.Get("embeddedContentRender"), "version-at-save")
+	assert.Equal(t, p.Get("trigger"), "viewed")
+	assert.Empty(t, addContentByIDQueryParams(ContentByIDQuery{}))
+}
+
+func Test_CreateContent(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/wiki/rest/api/content/", r.URL.Path)
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+
+		var got map[string]interface{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&got))
+		assert.Equal(t, map[string]interface{}{
+			"type":      "blogpost",
+			"title":     "Release notes",
+			"status":    "draft",
+			"space":     map[string]interface{}{"key": "DOC"},
+			"ancestors": []interface{}{map[string]interface{}{"id": "7"}},
+			"body": map[string]interface{}{
+				"atlas_doc_format": map[string]interface{}{"value": `{"type":"doc"}`, "representation": "atlas_doc_format"},
+			},
+		}, got)
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"99","type":"blogpost","status":"draft","title":"Release notes","_links":{"webui":"/spaces/DOC/blog/99"}}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	c, err := api.CreateContent(context.Background(), CreateContentRequest{
+		Type:     "blogpost",
+		Title:    "Release notes",
+		SpaceKey: "DOC",
+		ParentID: "7",
+		Body:     ContentBody{Value: `{"type":"doc"}`, Representation: RepresentationAtlasDocFormat},
+		Status:   "draft",
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "99", c.ID)
+	assert.Equal(t, "/spaces/DOC/blog/99", c.Links.Webui)
+}
+
+func Test_CreateContent_Validation(t *testing.T) {
+	api, err := NewAPI("https://test.test", "username", "token")
+	assert.Nil(t, err)
+
+	_, err = api.CreateContent(context.Background(), CreateContentRequest{SpaceKey: "DOC"})
+	assert.Equal(t, "title empty", err.Error())
+
+	_, err = api.CreateContent(context.Background(), CreateContentRequest{Title: "Title"})
+	assert.Equal(t, "space key empty", err.Error())
+}
+
+func TestBodyPayload(t *testing.T) {
+	assert.Equal(t, map[string]ContentBody{
+		"storage": {Value: "<p/>", Representation: "storage"},
+	}, bodyPayload(ContentBody{Value: "<p/>"}))
+}
+
+// versionedPageServer serves page 42 and accepts updates carrying the next version number.
+// The first `conflicts` updates are rejected after a concurrent edit bumped the version.
+func versionedPageServer(t *testing.T, conflicts int) (*httptest.Server, *Results) {
+	page := &Results{ID: "42", Type: "page", Title: "Page", Body: &Body{Storage: &Storage{Value: "v1", Representation: "storage"}}, Version: &Version{Number: 1}}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet:
+			b, _ := json.Marshal(page)
+			w.Write(b)
+		case http.MethodPut:
+			var p contentPayload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+			if conflicts > 0 {
+				conflicts--
+				page.Version.Number++
+				page.Body.Storage.Value += "+"
+			}
+			if p.Version.Number != page.Version.Number+1 {
+				http.Error(w, `{"statusCode":409,"message":"Version must be incremented on update."}`, http.StatusConflict)
+				return
+			}
+			page.Title = p.Title
+			if storage, ok := p.Body["storage"]; ok {
+				page.Body.Storage.Value = storage.Value
+			}
+			if adf, ok := p.Body["atlas_doc_format"]; ok {
+				page.Body.AtlasDocFormat = &Storage{Value: adf.Value, Representation: RepresentationAtlasDocFormat}
+			}
+			page.Version = &Version{Number: p.Version.Number, Message: p.Version.Message, MinorEdit: p.Version.MinorEdit}
+			b, _ := json.Marshal(page)
+			w.Write(b)
+		}
+	}))
+	return server, page
+}
+
+func Test_UpdateContent(t *testing.T) {
+	server, page := versionedPageServer(t, 0)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	c, err := api.UpdateContent(context.Background(), Results{
+		ID:   "42",
+		Body: &Body{Storage: &Storage{Value: "v2"}},
+	}, UpdateContentOptions{VersionMessage: "fix typo", MinorEdit: true})
+	assert.Nil(t, err)
+	assert.Equal(t, &Version{Number: 2, Message: "fix typo", MinorEdit: true}, c.Version)
+	assert.Equal(t, "Page", page.Title)
+	assert.Equal(t, "v2", page.Body.Storage.Value)
+
+	_, err = api.UpdateContent(context.Background(), Results{ID: "42", Title: "Page", Type: "page", Version: &Version{Number: 1}}, UpdateContentOptions{})
+	assert.True(t, errors.Is(err, ErrConflict))
+
+	_, err = api.UpdateContent(context.Background(), Results{}, UpdateContentOptions{})
+	assert.Equal(t, "id empty", err.Error())
+}
+
+func Test_UpdateContentRepresentation(t *testing.T) {
+	server, page := versionedPageServer(t, 0)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	edited := Results{
+		ID:      "42",
+		Title:   "Page",
+		Type:    "page",
+		Version: &Version{Number: 1},
+		Body: &Body{
+			Storage:        &Storage{Value: "v1", Representation: RepresentationStorage},
+			AtlasDocFormat: &Storage{Value: `{"version":1}`, Representation: RepresentationAtlasDocFormat},
+		},
+	}
+	_, err = api.UpdateContent(context.Background(), edited, UpdateContentOptions{})
+	assert.Equal(t, "body holds several representations (storage, atlas_doc_format), set the one to save", err.Error())
+	assert.Equal(t, 1, page.Version.Number)
+
+	_, err = api.UpdateContent(context.Background(), edited, UpdateContentOptions{Representation: RepresentationAtlasDocFormat})
+	assert.Nil(t, err)
+	assert.Equal(t, `{"version":1}`, page.Body.AtlasDocFormat.Value)
+	assert.Equal(t, 2, page.Version.Number)
+}
+
+func Test_UpdateContentFunc(t *testing.T) {
+	server, page := versionedPageServer(t, 2)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	appendSuffix := func(c *Results) error {
+		c.Body.Storage.Value += " edited"
+		return nil
+	}
+
+	_, err = api.UpdateContentFunc(context.Background(), "42", appendSuffix, UpdateContentFuncOptions{ConflictRetries: 1})
+	assert.True(t, errors.Is(err, ErrConflict))
+
+	c, err := api.UpdateContentFunc(context.Background(), "42", appendSuffix, UpdateContentFuncOptions{ConflictRetries: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, c.Version.Number)
+	assert.Equal(t, "v1++ edited", page.Body.Storage.Value)
+
+	stop := errors.New("stop")
+	_, err = api.UpdateContentFunc(context.Background(), "42", func(*Results) error { return stop }, UpdateContentFuncOptions{})
+	assert.Equal(t, stop, err)
+}
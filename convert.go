@@ -0,0 +1,122 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Status values of an asynchronous body conversion
+const (
+	ConvertStatusWorking   = "WORKING"
+	ConvertStatusQueued    = "QUEUED"
+	ConvertStatusFailed    = "FAILED"
+	ConvertStatusCompleted = "COMPLETED"
+	ConvertStatusRerunning = "RERUNNING"
+)
+
+// getConvertEndpoint creates the correct api endpoint by given target representation
+func (a *api) getConvertEndpoint(to string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/contentbody/convert/" + to)
+}
+
+// getAsyncConvertEndpoint creates the correct api endpoint by given target representation or async id
+func (a *api) getAsyncConvertEndpoint(toOrID string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/contentbody/convert/async/" + toOrID)
+}
+
+// ConvertBody converts body into the representation to, without creating any content
+func (a *api) ConvertBody(ctx context.Context, to string, body ContentBody, query ConvertBodyQuery) (*Storage, error) {
+	ep, err := a.getConvertEndpoint(to)
+	if err != nil {
+		return nil, err
+	}
+	ep.RawQuery = addConvertBodyQueryParams(query).Encode()
+
+	var converted Storage
+	err = a.sendRequest(ctx, ep, http.MethodPost, body, &converted)
+	if err != nil {
+		return nil, err
+	}
+	return &converted, nil
+}
+
+// ConvertBodyAsync starts an asynchronous conversion of body into the representation to
+// and returns the id to retrieve its result with GetAsyncConvertedBody
+func (a *api) ConvertBodyAsync(ctx context.Context, to string, body ContentBody, query ConvertBodyQuery) (string, error) {
+	ep, err := a.getAsyncConvertEndpoint(to)
+	if err != nil {
+		return "", err
+	}
+	ep.RawQuery = addConvertBodyQueryParams(query).Encode()
+
+	var res struct {
+		AsyncID string `json:"asyncId"`
+	}
+	err = a.sendRequest(ctx, ep, http.MethodPost, body, &res)
+	if err != nil {
+		return "", err
+	}
+	return res.AsyncID, nil
+}
+
+// GetAsyncConvertedBody gets the state, and once completed the result, of an asynchronous conversion
+func (a *api) GetAsyncConvertedBody(ctx context.Context, asyncID string) (*AsyncConvertedBody, error) {
+	ep, err := a.getAsyncConvertEndpoint(asyncID)
+	if err != nil {
+		return nil, err
+	}
+
+	var converted AsyncConvertedBody
+	err = a.sendRequest(ctx, ep, http.MethodGet, nil, &converted)
+	if err != nil {
+		return nil, err
+	}
+	return &converted, nil
+}
+
+// WaitForConvertedBody polls an asynchronous conversion every pollInterval until it completes or fails.
+// A pollInterval which is not positive defaults to one second.
+// A ConversionError is returned when the conversion fails, or when ctx expires before it finished.
+func (a *api) WaitForConvertedBody(ctx context.Context, asyncID string, pollInterval time.Duration) (*AsyncConvertedBody, error) {
+	var last *AsyncConvertedBody
+	err := poll(ctx, pollInterval, func() (bool, error) {
+		converted, err := a.GetAsyncConvertedBody(ctx, asyncID)
+		if err != nil {
+			return false, err
+		}
+		last = converted
+		return converted.Status == ConvertStatusCompleted || converted.Status == ConvertStatusFailed, nil
+	})
+	if err != nil {
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return nil, &ConversionError{ID: asyncID, Converted: last, Err: ErrTaskTimeout, Cause: err}
+		}
+		return nil, err
+	}
+	if last.Status == ConvertStatusFailed {
+		return last, &ConversionError{ID: asyncID, Converted: last, Err: ErrTaskFailed}
+	}
+	return last, nil
+}
+
+// addConvertBodyQueryParams adds the defined query parameters
+func addConvertBodyQueryParams(query ConvertBodyQuery) *url.Values {
+	data := url.Values{}
+	if query.ContentIDContext != "" {
+		data.Set("contentIdContext", query.ContentIDContext)
+	}
+	if query.SpaceKeyContext != "" {
+		data.Set("spaceKeyContext", query.SpaceKeyContext)
+	}
+	if query.EmbeddedContentRender != "" {
+		data.Set("embeddedContentRender", query.EmbeddedContentRender)
+	}
+	if len(query.Expand) != 0 {
+		data.Set("expand", strings.Join(query.Expand, ","))
+	}
+	return &data
+}
@@ -0,0 +1,110 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func convertServer(t *testing.T) *httptest.Server {
+	polls := 0
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/rest/api/contentbody/convert/view":
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Equal(t, "65538", r.URL.Query().Get("contentIdContext"))
+			assert.Equal(t, "webresource.tags.all", r.URL.Query().Get("expand"))
+			var body ContentBody
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&body))
+			assert.Equal(t, ContentBody{Value: "<p>x</p>", Representation: RepresentationStorage}, body)
+			w.Write([]byte(`{"value":"<p>x</p>","representation":"view","webresource":{"tags":{"all":"<link>"}}}`))
+		case "/wiki/rest/api/contentbody/convert/async/storage":
+			w.Write([]byte(`{"asyncId":"job-1"}`))
+		case "/wiki/rest/api/contentbody/convert/async/job-1":
+			polls++
+			if polls < 3 {
+				w.Write([]byte(`{"status":"WORKING"}`))
+				return
+			}
+			w.Write([]byte(`{"status":"COMPLETED","value":"<p>x</p>","representation":"storage"}`))
+		case "/wiki/rest/api/contentbody/convert/async/job-2":
+			w.Write([]byte(`{"status":"FAILED","error":"Unsupported macro"}`))
+		case "/wiki/rest/api/contentbody/convert/async/job-3":
+			w.Write([]byte(`{"status":"QUEUED"}`))
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_ConvertBody(t *testing.T) {
+	server := convertServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	s, err := api.ConvertBody(context.Background(), RepresentationView,
+		ContentBody{Value: "<p>x</p>", Representation: RepresentationStorage},
+		ConvertBodyQuery{ContentIDContext: "65538", Expand: []string{"webresource.tags.all"}})
+	assert.Nil(t, err)
+	assert.Equal(t, "view", s.Representation)
+	assert.Equal(t, "<link>", s.Webresource.Tags.All)
+}
+
+func Test_ConvertBodyAsync(t *testing.T) {
+	server := convertServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	id, err := api.ConvertBodyAsync(ctx, RepresentationStorage, ContentBody{Value: "{}", Representation: RepresentationAtlasDocFormat}, ConvertBodyQuery{})
+	assert.Nil(t, err)
+	assert.Equal(t, "job-1", id)
+
+	c, err := api.GetAsyncConvertedBody(ctx, id)
+	assert.Nil(t, err)
+	assert.Equal(t, ConvertStatusWorking, c.Status)
+
+	c, err = api.WaitForConvertedBody(ctx, id, time.Millisecond)
+	assert.Nil(t, err)
+	assert.Equal(t, "<p>x</p>", c.Value)
+
+	c, err = api.WaitForConvertedBody(ctx, "job-2", time.Millisecond)
+	assert.True(t, errors.Is(err, ErrTaskFailed))
+	assert.Equal(t, "long running task failed: conversion job-2: Unsupported macro", err.Error())
+	var convErr *ConversionError
+	assert.True(t, errors.As(err, &convErr))
+	assert.Equal(t, c, convErr.Converted)
+	assert.Equal(t, ConvertStatusFailed, c.Status)
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = api.WaitForConvertedBody(timeout, "job-3", 5*time.Millisecond)
+	assert.True(t, errors.Is(err, ErrTaskTimeout))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.True(t, errors.As(err, &convErr))
+	assert.Equal(t, ConvertStatusQueued, convErr.Converted.Status)
+}
+
+func TestAddConvertBodyQueryParams(t *testing.T) {
+	p := addConvertBodyQueryParams(ConvertBodyQuery{
+		ContentIDContext:      "1",
+		SpaceKeyContext:       "DOC",
+		EmbeddedContentRender: "current",
+		Expand:                []string{"embeddedContent", "mediaToken"},
+	})
+
+	assert.Equal(t, "1", p.Get("contentIdContext"))
+	assert.Equal(t, "DOC", p.Get("spaceKeyContext"))
+	assert.Equal(t, "current", p.Get("embeddedContentRender"))
+	assert.Equal(t, "embeddedContent,mediaToken", p.Get("expand"))
+}
@@ -0,0 +1,194 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// Destination types of a page copy
+const (
+	CopyToSpace        = "space"         // root page of a space, Destination is the space key
+	CopyToParentPage   = "parent_page"   // child of a page, Destination is the page id
+	CopyToExistingPage = "existing_page" // replaces the content of a page, Destination is the page id
+)
+
+// maxCopyTitleAttempts bounds the numbered titles tried when a renamed copy still collides
+const maxCopyTitleAttempts = 100
+
+// getCopyEndpoint creates the correct api endpoint by given id
+func (a *api) getCopyEndpoint(id string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/content/" + id + "/copy")
+}
+
+// getHierarchyCopyEndpoint creates the correct api endpoint by given id
+func (a *api) getHierarchyCopyEndpoint(id string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/content/" + id + "/pagehierarchy/copy")
+}
+
+// CopyPage copies a single page. When the title of the copy is already used in the destination
+// space the copy is renamed with opts.TitlePrefix and opts.TitleSuffix, numbered if that title
+// is taken too. Without a prefix or suffix a TitleCollisionError is returned.
+func (a *api) CopyPage(ctx context.Context, id string, opts CopyPageOptions) (*Results, error) {
+	if opts.DestinationType == "" || opts.Destination == "" {
+		return nil, errors.New("destination empty")
+	}
+
+	title := opts.Title
+	if opts.DestinationType != CopyToExistingPage {
+		var err error
+		if title == "" {
+			source, err := a.GetContentByID(ctx, id, ContentByIDQuery{})
+			if err != nil {
+				return nil, err
+			}
+			title = source.Title
+		}
+
+		spaceKey := opts.Destination
+		if opts.DestinationType == CopyToParentPage {
+			if spaceKey, err = a.pageSpaceKey(ctx, opts.Destination); err != nil {
+				return nil, err
+			}
+		}
+		if title, err = a.freeTitle(ctx, spaceKey, title, opts.TitlePrefix, opts.TitleSuffix); err != nil {
+			return nil, err
+		}
+	}
+
+	ep, err := a.getCopyEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+	if len(opts.Expand) != 0 {
+		ep.RawQuery = url.Values{"expand": {strings.Join(opts.Expand, ",")}}.Encode()
+	}
+
+	payload := copyPagePayload{
+		CopyAttachments: opts.CopyAttachments,
+		CopyPermissions: opts.CopyPermissions,
+		CopyProperties:  opts.CopyProperties,
+		CopyLabels:      opts.CopyLabels,
+		Destination:     copyDestinationPayload{Type: opts.DestinationType, Value: opts.Destination},
+		PageTitle:       title,
+	}
+
+	var content Results
+	err = a.sendRequest(ctx, ep, http.MethodPost, payload, &content)
+	if err != nil {
+		return nil, err
+	}
+	return &content, nil
+}
+
+// CopyPageHierarchy copies a page and its descendants below the destination page.
+// The copy runs as a long running task, which is returned to be waited for with WaitForTask.
+// The copied titles get opts.TitlePrefix and opts.TitleSearch replaced by opts.TitleReplace;
+// the copy is refused with a TitleCollisionError when one of them is already used in the destination space.
+func (a *api) CopyPageHierarchy(ctx context.Context, id string, opts CopyHierarchyOptions) (*LongTaskRef, error) {
+	if opts.DestinationPageID == "" {
+		return nil, errors.New("destination page empty")
+	}
+
+	spaceKey, err := a.pageSpaceKey(ctx, opts.DestinationPageID)
+	if err != nil {
+		return nil, err
+	}
+	root, err := a.GetContentByID(ctx, id, ContentByIDQuery{})
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := a.PaginateDescendantPages(ctx, id, nil).All(0)
+	if err != nil {
+		return nil, err
+	}
+
+	var collisions []string
+	for _, p := range append([]Results{*root}, descendants...) {
+		title := p.Title
+		if opts.TitleSearch != "" {
+			title = strings.ReplaceAll(title, opts.TitleSearch, opts.TitleReplace)
+		}
+		title = opts.TitlePrefix + title
+		existing, err := a.pagesTitled(ctx, spaceKey, title)
+		if err != nil {
+			return nil, err
+		}
+		if len(existing) != 0 {
+			collisions = append(collisions, title)
+		}
+	}
+	if len(collisions) != 0 {
+		return nil, &TitleCollisionError{SpaceKey: spaceKey, Titles: collisions}
+	}
+
+	ep, err := a.getHierarchyCopyEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := copyHierarchyPayload{
+		CopyAttachments:   opts.CopyAttachments,
+		CopyPermissions:   opts.CopyPermissions,
+		CopyProperties:    opts.CopyProperties,
+		CopyLabels:        opts.CopyLabels,
+		CopyDescendants:   true,
+		DestinationPageID: opts.DestinationPageID,
+		TitleOptions: copyTitleOptionsPayload{
+			Prefix:  opts.TitlePrefix,
+			Search:  opts.TitleSearch,
+			Replace: opts.TitleReplace,
+		},
+	}
+
+	var task LongTaskRef
+	err = a.sendRequest(ctx, ep, http.MethodPost, payload, &task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// freeTitle returns title when it is unused in the space, or the first unused renamed title
+func (a *api) freeTitle(ctx context.Context, spaceKey string, title string, prefix string, suffix string) (string, error) {
+	existing, err := a.pagesTitled(ctx, spaceKey, title)
+	if err != nil || len(existing) == 0 {
+		return title, err
+	}
+	if prefix == "" && suffix == "" {
+		return "", &TitleCollisionError{SpaceKey: spaceKey, Titles: []string{title}}
+	}
+
+	for n := 1; n <= maxCopyTitleAttempts; n++ {
+		renamed := prefix + title + suffix
+		if n > 1 {
+			renamed += " (" + strconv.Itoa(n) + ")"
+		}
+		existing, err := a.pagesTitled(ctx, spaceKey, renamed)
+		if err != nil || len(existing) == 0 {
+			return renamed, err
+		}
+	}
+	return "", &TitleCollisionError{SpaceKey: spaceKey, Titles: []string{prefix + title + suffix}}
+}
+
+// pagesTitled returns the pages of a space with the given title
+func (a *api) pagesTitled(ctx context.Context, spaceKey string, title string) ([]Results, error) {
+	c, err := a.GetContentWithContext(ctx, ContentQuery{SpaceKey: spaceKey, Title: title, Type: "page"})
+	if err != nil {
+		return nil, err
+	}
+	return c.Results, nil
+}
+
+// pageSpaceKey returns the key of the space of a page
+func (a *api) pageSpaceKey(ctx context.Context, id string) (string, error) {
+	page, err := a.GetContentByID(ctx, id, ContentByIDQuery{Expand: []string{"space"}})
+	if err != nil {
+		return "", err
+	}
+	return page.spaceKey(), nil
+}
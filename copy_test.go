@@ -0,0 +1,135 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// copyServer serves the pages 1 "Template" with the child 2 "Template Checklist" in space TPL,
+// the page 50 in space PRJ, and the titles taken in PRJ
+func copyServer(t *testing.T, taken map[string]bool, copied *[]byte) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/rest/api/content/1":
+			w.Write([]byte(`{"id":"1","type":"page","title":"Template","space":{"key":"TPL"}}`))
+		case "/wiki/rest/api/content/50":
+			assert.Equal(t, "space", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"id":"50","type":"page","title":"Projects","space":{"key":"PRJ"}}`))
+		case "/wiki/rest/api/content/1/descendant/page":
+			w.Write([]byte(`{"results":[{"id":"2","type":"page","title":"Template Checklist"}]}`))
+		case "/wiki/rest/api/content/":
+			assert.Equal(t, "PRJ", r.URL.Query().Get("spaceKey"))
+			assert.Equal(t, "page", r.URL.Query().Get("type"))
+			if taken[r.URL.Query().Get("title")] {
+				w.Write([]byte(`{"results":[{"id":"99","type":"page","title":"` + r.URL.Query().Get("title") + `"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results":[]}`))
+		case "/wiki/rest/api/content/1/copy":
+			assert.Equal(t, http.MethodPost, r.Method)
+			*copied, _ = ioutil.ReadAll(r.Body)
+			var p copyPagePayload
+			assert.Nil(t, json.Unmarshal(*copied, &p))
+			w.Write([]byte(`{"id":"60","type":"page","title":"` + p.PageTitle + `"}`))
+		case "/wiki/rest/api/content/1/pagehierarchy/copy":
+			assert.Equal(t, http.MethodPost, r.Method)
+			*copied, _ = ioutil.ReadAll(r.Body)
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":"task-7","links":{"status":"/rest/api/longtask/task-7"}}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_CopyPage(t *testing.T) {
+	taken := map[string]bool{}
+	var copied []byte
+	server := copyServer(t, taken, &copied)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	c, err := api.CopyPage(ctx, "1", CopyPageOptions{
+		DestinationType: CopyToParentPage,
+		Destination:     "50",
+		CopyAttachments: true,
+		CopyLabels:      true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "Template", c.Title)
+	assert.JSONEq(t, `{"copyAttachments":true,"copyPermissions":false,"copyProperties":false,"copyLabels":true,`+
+		`"destination":{"type":"parent_page","value":"50"},"pageTitle":"Template"}`, string(copied))
+
+	taken["Kickoff"] = true
+	_, err = api.CopyPage(ctx, "1", CopyPageOptions{DestinationType: CopyToSpace, Destination: "PRJ", Title: "Kickoff"})
+	var collision *TitleCollisionError
+	assert.True(t, errors.As(err, &collision))
+	assert.True(t, errors.Is(err, ErrTitleCollision))
+	assert.Equal(t, "title already used in space PRJ: Kickoff", err.Error())
+
+	c, err = api.CopyPage(ctx, "1", CopyPageOptions{DestinationType: CopyToSpace, Destination: "PRJ", Title: "Kickoff", TitleSuffix: " (copy)"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Kickoff (copy)", c.Title)
+
+	taken["Kickoff (copy)"] = true
+	taken["Kickoff (copy) (2)"] = true
+	c, err = api.CopyPage(ctx, "1", CopyPageOptions{DestinationType: CopyToSpace, Destination: "PRJ", Title: "Kickoff", TitleSuffix: " (copy)"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Kickoff (copy) (3)", c.Title)
+
+	// the content of an existing page is replaced, its title is not checked
+	c, err = api.CopyPage(ctx, "1", CopyPageOptions{DestinationType: CopyToExistingPage, Destination: "50"})
+	assert.Nil(t, err)
+	assert.Equal(t, "", c.Title)
+
+	_, err = api.CopyPage(ctx, "1", CopyPageOptions{})
+	assert.Equal(t, "destination empty", err.Error())
+}
+
+func Test_CopyPageHierarchy(t *testing.T) {
+	taken := map[string]bool{"Template": true}
+	var copied []byte
+	server := copyServer(t, taken, &copied)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	_, err = api.CopyPageHierarchy(ctx, "1", CopyHierarchyOptions{DestinationPageID: "50"})
+	var collision *TitleCollisionError
+	assert.True(t, errors.As(err, &collision))
+	assert.Equal(t, &TitleCollisionError{SpaceKey: "PRJ", Titles: []string{"Template"}}, collision)
+	assert.Nil(t, copied)
+
+	task, err := api.CopyPageHierarchy(ctx, "1", CopyHierarchyOptions{
+		DestinationPageID: "50",
+		TitlePrefix:       "Apollo ",
+		TitleSearch:       "Template",
+		TitleReplace:      "Project",
+		CopyPermissions:   true,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, "task-7", task.ID)
+	assert.JSONEq(t, `{"copyAttachments":false,"copyPermissions":true,"copyProperties":false,"copyLabels":false,`+
+		`"copyDescendants":true,"destinationPageId":"50",`+
+		`"titleOptions":{"prefix":"Apollo ","search":"Template","replace":"Project"}}`, string(copied))
+
+	taken["Apollo Project Checklist"] = true
+	_, err = api.CopyPageHierarchy(ctx, "1", CopyHierarchyOptions{DestinationPageID: "50", TitlePrefix: "Apollo ", TitleSearch: "Template", TitleReplace: "Project"})
+	assert.True(t, errors.As(err, &collision))
+	assert.Equal(t, []string{"Apollo Project Checklist"}, collision.Titles)
+
+	_, err = api.CopyPageHierarchy(ctx, "1", CopyHierarchyOptions{})
+	assert.Equal(t, "destination page empty", err.Error())
+}
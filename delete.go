@@ -0,0 +1,75 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+)
+
+// TrashContent moves a page or blog post to the trash
+func (a *api) TrashContent(ctx context.Context, id string) error {
+	ep, err := a.getContentIDEndpoint(id)
+	if err != nil {
+		return err
+	}
+
+	err = a.sendRequest(ctx, ep, http.MethodDelete, nil, nil)
+	if err != nil {
+		return a.contentStateError(ctx, id, err, func(status string) bool { return status == "trashed" }, ErrAlreadyTrashed)
+	}
+	return nil
+}
+
+// PurgeContent permanently deletes trashed content
+func (a *api) PurgeContent(ctx context.Context, id string) error {
+	ep, err := a.getContentIDEndpoint(id)
+	if err != nil {
+		return err
+	}
+	ep.RawQuery = "status=trashed"
+
+	err = a.sendRequest(ctx, ep, http.MethodDelete, nil, nil)
+	if err != nil {
+		return a.contentStateError(ctx, id, err, func(status string) bool { return status != "trashed" }, ErrNotTrashed)
+	}
+	return nil
+}
+
+// RestoreContent restores trashed content, saving it as a new current version
+func (a *api) RestoreContent(ctx context.Context, id string) (*Results, error) {
+	trashed, err := a.GetContentByID(ctx, id, ContentByIDQuery{
+		Expand: []string{"version"},
+		Status: []string{"trashed"},
+	})
+	if err != nil {
+		return nil, a.contentStateError(ctx, id, err, func(status string) bool { return status != "trashed" }, ErrNotTrashed)
+	}
+
+	trashed.Status = "current"
+	return a.UpdateContent(ctx, *trashed, UpdateContentOptions{})
+}
+
+// DeleteContentVersion deletes a historical version of the content
+func (a *api) DeleteContentVersion(ctx context.Context, id string, version int) error {
+	ep, err := a.getContentGenericEndpoint(id, "version/"+strconv.Itoa(version))
+	if err != nil {
+		return err
+	}
+	return a.sendRequest(ctx, ep, http.MethodDelete, nil, nil)
+}
+
+// contentStateError explains a failed operation: when the content is found in a state
+// matching wrongState a ContentStateError is returned, otherwise err is returned as is.
+func (a *api) contentStateError(ctx context.Context, id string, err error, wrongState func(string) bool, stateErr error) error {
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || errors.Is(err, ErrForbidden) || errors.Is(err, ErrUnauthorized) {
+		return err
+	}
+
+	c, lookupErr := a.GetContentByID(ctx, id, ContentByIDQuery{Status: []string{"any"}})
+	if lookupErr != nil || !wrongState(c.Status) {
+		return err
+	}
+	return &ContentStateError{ID: id, Status: c.Status, Err: stateErr, Cause: err}
+}
@@ -0,0 +1,118 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// trashServer serves page 1, whose status is kept in *status, and page 2 which can not be deleted
+func trashServer(t *testing.T, status *string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case r.URL.Path == "/wiki/rest/api/content/2":
+			http.Error(w, `{"statusCode":403,"message":"Not permitted"}`, http.StatusForbidden)
+		case r.URL.Path == "/wiki/rest/api/content/1/version/2" && r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path != "/wiki/rest/api/content/1" || *status == "purged":
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			if query.Get("status") != "any" && query.Get("status") != *status {
+				http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+				return
+			}
+			w.Write([]byte(`{"id":"1","type":"page","title":"Page","status":"` + *status + `","version":{"number":3}}`))
+		case r.Method == http.MethodDelete && query.Get("status") == "trashed":
+			if *status != "trashed" {
+				http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+				return
+			}
+			*status = "purged"
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodDelete:
+			if *status != "current" {
+				http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+				return
+			}
+			*status = "trashed"
+			w.WriteHeader(http.StatusNoContent)
+		case r.Method == http.MethodPut:
+			var p contentPayload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+			assert.Equal(t, 4, p.Version.Number)
+			*status = p.Status
+			w.Write([]byte(`{"id":"1","type":"page","title":"Page","status":"` + *status + `","version":{"number":4}}`))
+		}
+	}))
+}
+
+func Test_TrashRestorePurge(t *testing.T) {
+	status := "current"
+	server := trashServer(t, &status)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	_, err = api.RestoreContent(ctx, "1")
+	assert.True(t, errors.Is(err, ErrNotTrashed))
+
+	err = api.PurgeContent(ctx, "1")
+	assert.True(t, errors.Is(err, ErrNotTrashed))
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	assert.Nil(t, api.TrashContent(ctx, "1"))
+	assert.Equal(t, "trashed", status)
+
+	err = api.TrashContent(ctx, "1")
+	assert.True(t, errors.Is(err, ErrAlreadyTrashed))
+	var stateErr *ContentStateError
+	assert.True(t, errors.As(err, &stateErr))
+	assert.Equal(t, "trashed", stateErr.Status)
+	assert.Equal(t, "content already trashed: content 1 is trashed", err.Error())
+
+	c, err := api.RestoreContent(ctx, "1")
+	assert.Nil(t, err)
+	assert.Equal(t, "current", c.Status)
+	assert.Equal(t, "current", status)
+
+	assert.Nil(t, api.TrashContent(ctx, "1"))
+	assert.Nil(t, api.PurgeContent(ctx, "1"))
+	assert.Equal(t, "purged", status)
+
+	err = api.TrashContent(ctx, "1")
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrAlreadyTrashed))
+}
+
+func Test_TrashContent_Forbidden(t *testing.T) {
+	status := "current"
+	server := trashServer(t, &status)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	err = api.TrashContent(context.Background(), "2")
+	assert.True(t, errors.Is(err, ErrForbidden))
+	assert.Equal(t, "forbidden: 403 Forbidden: Not permitted", err.Error())
+}
+
+func Test_DeleteContentVersion(t *testing.T) {
+	status := "current"
+	server := trashServer(t, &status)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	assert.Nil(t, api.DeleteContentVersion(context.Background(), "1", 2))
+	assert.True(t, errors.Is(api.DeleteContentVersion(context.Background(), "1", 5), ErrNotFound))
+}
@@ -0,0 +1,266 @@
+package confluentcloud
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by APIError through errors.Is
+var (
+	ErrNotFound     = errors.New("not found")
+	ErrUnauthorized = errors.New("unauthorized")
+	ErrForbidden    = errors.New("forbidden")
+	ErrConflict     = errors.New("conflict")
+	ErrRateLimited  = errors.New("rate limited")
+)
+
+// Sentinel errors matched by ContentStateError through errors.Is
+var (
+	ErrAlreadyTrashed = errors.New("content already trashed")
+	ErrNotTrashed     = errors.New("content not trashed")
+)
+
+// ErrInvalidLabel is returned when a label name breaks the Confluence label rules
+var ErrInvalidLabel = errors.New("invalid label")
+
+// Sentinel errors returned when a downloaded attachment fails verification
+var (
+	ErrSizeMismatch     = errors.New("attachment size mismatch")
+	ErrChecksumMismatch = errors.New("attachment checksum mismatch")
+	ErrSizeUnknown      = errors.New("attachment size unknown") // the size to verify against was not read
+)
+
+// ErrUnsafeMove is returned when moving a subtree to another space would break titles or links
+var ErrUnsafeMove = errors.New("unsafe move")
+
+// ErrPositionUnsupported is returned when a page cannot be placed before or after its target
+// because it is moved by updating its ancestors
+var ErrPositionUnsupported = errors.New("move position cannot be applied without the move endpoint")
+
+// ErrTitleCollision is matched by TitleCollisionError through errors.Is
+var ErrTitleCollision = errors.New("title already used")
+
+// Sentinel errors matched by LongTaskError and ConversionError through errors.Is
+var (
+	ErrTaskFailed  = errors.New("long running task failed")
+	ErrTaskTimeout = errors.New("long running task timed out")
+)
+
+// APIError is returned when Confluence answers with an unexpected status code
+type APIError struct {
+	StatusCode int
+	Status     string
+	Method     string
+	URL        string
+	Body       []byte         // raw response body
+	Response   *ErrorResponse // decoded Confluence error payload, nil if the body is not one
+}
+
+// ErrorResponse is the error payload returned by the Confluence REST API
+type ErrorResponse struct {
+	StatusCode int       `json:"statusCode,omitempty"`
+	Message    string    `json:"message,omitempty"`
+	Reason     string    `json:"reason,omitempty"`
+	Data       ErrorData `json:"data,omitempty"`
+}
+
+// ErrorData holds the detailed errors of an ErrorResponse
+type ErrorData struct {
+	Authorized            bool         `json:"authorized"`
+	Valid                 bool         `json:"valid"`
+	AllowedInReadOnlyMode bool         `json:"allowedInReadOnlyMode"`
+	Successful            bool         `json:"successful"`
+	Errors                []ErrorEntry `json:"errors,omitempty"`
+}
+
+// ErrorEntry is a single error of ErrorData
+type ErrorEntry struct {
+	Message ErrorMessage `json:"message"`
+}
+
+// ErrorMessage is a translatable Confluence message
+type ErrorMessage struct {
+	Key         string        `json:"key,omitempty"`
+	Args        []interface{} `json:"args,omitempty"`
+	Translation string        `json:"translation,omitempty"`
+}
+
+// newAPIError builds an APIError from a response and its already read body
+func newAPIError(req *http.Request, resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Body:       body,
+	}
+	if req != nil {
+		e.Method = req.Method
+		if req.URL != nil {
+			e.URL = req.URL.String()
+		}
+	}
+
+	var r ErrorResponse
+	if err := json.Unmarshal(body, &r); err == nil && (r.StatusCode != 0 || r.Message != "") {
+		e.Response = &r
+	}
+	return e
+}
+
+// Error implements the error interface
+func (e *APIError) Error() string {
+	var msg string
+	switch e.StatusCode {
+	case http.StatusUnauthorized:
+		msg = "authentication failed"
+	case http.StatusForbidden:
+		msg = fmt.Sprintf("forbidden: %s", e.Status)
+	case http.StatusNotFound:
+		msg = fmt.Sprintf("not found: %s", e.Status)
+	case http.StatusConflict:
+		msg = fmt.Sprintf("conflict: %s", e.Status)
+	case http.StatusTooManyRequests:
+		msg = fmt.Sprintf("rate limited: %s", e.Status)
+	case http.StatusServiceUnavailable:
+		msg = fmt.Sprintf("service is not available: %s", e.Status)
+	case http.StatusInternalServerError:
+		msg = fmt.Sprintf("internal server error: %s", e.Status)
+	default:
+		msg = fmt.Sprintf("unknown response status: %s", e.Status)
+	}
+	if m := e.Message(); m != "" {
+		msg += ": " + m
+	}
+	return msg
+}
+
+// Message returns the most specific message found in the Confluence error payload
+func (e *APIError) Message() string {
+	if e.Response == nil {
+		return ""
+	}
+	if e.Response.Message != "" {
+		return e.Response.Message
+	}
+	for _, entry := range e.Response.Data.Errors {
+		if entry.Message.Translation != "" {
+			return entry.Message.Translation
+		}
+	}
+	return ""
+}
+
+// Is reports whether the error matches one of the sentinel errors
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrConflict:
+		return e.StatusCode == http.StatusConflict
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// ContentStateError is returned when content is not in the state an operation requires
+type ContentStateError struct {
+	ID     string
+	Status string // current status of the content
+	Err    error  // ErrAlreadyTrashed or ErrNotTrashed
+	Cause  error  // error returned by Confluence
+}
+
+// Error implements the error interface
+func (e *ContentStateError) Error() string {
+	return fmt.Sprintf("%s: content %s is %s", e.Err, e.ID, e.Status)
+}
+
+// Unwrap returns the error returned by Confluence
+func (e *ContentStateError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is the state error
+func (e *ContentStateError) Is(target error) bool {
+	return target == e.Err
+}
+
+// TitleCollisionError is returned when copied pages would take titles already used in a space
+type TitleCollisionError struct {
+	SpaceKey string
+	Titles   []string
+}
+
+// Error implements the error interface
+func (e *TitleCollisionError) Error() string {
+	return fmt.Sprintf("%s in space %s: %s", ErrTitleCollision, e.SpaceKey, strings.Join(e.Titles, ", "))
+}
+
+// Is reports whether target is ErrTitleCollision
+func (e *TitleCollisionError) Is(target error) bool {
+	return target == ErrTitleCollision
+}
+
+// LongTaskError is returned when a long running task failed or did not finish in time
+type LongTaskError struct {
+	ID    string
+	Task  *LongTask // last state read, nil if the task was never read
+	Err   error     // ErrTaskFailed or ErrTaskTimeout
+	Cause error     // context error of a timeout
+}
+
+// Error implements the error interface
+func (e *LongTaskError) Error() string {
+	msg := fmt.Sprintf("%s: %s", e.Err, e.ID)
+	if e.Task != nil {
+		msg += fmt.Sprintf(" at %d%%", e.Task.PercentageComplete)
+		if m := e.Task.message(); m != "" {
+			msg += ": " + m
+		}
+	}
+	return msg
+}
+
+// Unwrap returns the context error of a timeout
+func (e *LongTaskError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is the task error
+func (e *LongTaskError) Is(target error) bool {
+	return target == e.Err
+}
+
+// ConversionError is returned when an asynchronous body conversion failed or did not finish in time
+type ConversionError struct {
+	ID        string
+	Converted *AsyncConvertedBody // last state read, nil if the conversion was never read
+	Err       error               // ErrTaskFailed or ErrTaskTimeout
+	Cause     error               // context error of a timeout
+}
+
+// Error implements the error interface
+func (e *ConversionError) Error() string {
+	msg := fmt.Sprintf("%s: conversion %s", e.Err, e.ID)
+	if e.Converted != nil && e.Converted.Error != "" {
+		msg += ": " + e.Converted.Error
+	}
+	return msg
+}
+
+// Unwrap returns the context error of a timeout
+func (e *ConversionError) Unwrap() error {
+	return e.Cause
+}
+
+// Is reports whether target is the conversion error
+func (e *ConversionError) Is(target error) bool {
+	return target == e.Err
+}
@@ -0,0 +1,83 @@
+package confluentcloud
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const notFoundPayload = `{
+	"statusCode": 404,
+	"data": {
+		"authorized": true,
+		"valid": true,
+		"allowedInReadOnlyMode": true,
+		"errors": [{"message": {"key": "content.not.found", "args": [], "translation": "No content found"}}],
+		"successful": false
+	},
+	"message": "No content found with id: ContentId{id=42}",
+	"reason": "Not Found"
+}`
+
+func TestAPIError_FromRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(notFoundPayload))
+	}))
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, api.endPoint.String()+"/content/42", nil)
+	assert.Nil(t, err)
+
+	_, err = api.Request(req)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.False(t, errors.Is(err, ErrForbidden))
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+	assert.Equal(t, http.MethodGet, apiErr.Method)
+	assert.Equal(t, server.URL+"/wiki/rest/api/content/42", apiErr.URL)
+	assert.Equal(t, notFoundPayload, string(apiErr.Body))
+	assert.NotNil(t, apiErr.Response)
+	assert.Equal(t, 404, apiErr.Response.StatusCode)
+	assert.Equal(t, "content.not.found", apiErr.Response.Data.Errors[0].Message.Key)
+	assert.Equal(t, "not found: 404 Not Found: No content found with id: ContentId{id=42}", err.Error())
+}
+
+func TestAPIError_Is(t *testing.T) {
+	testValues := []struct {
+		StatusCode int
+		Target     error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrForbidden},
+		{http.StatusConflict, ErrConflict},
+		{http.StatusTooManyRequests, ErrRateLimited},
+	}
+
+	for _, test := range testValues {
+		err := &APIError{StatusCode: test.StatusCode}
+		assert.True(t, errors.Is(err, test.Target))
+		assert.False(t, errors.Is(err, errors.New("other")))
+	}
+}
+
+func TestAPIError_Message(t *testing.T) {
+	err := newAPIError(nil, &http.Response{StatusCode: http.StatusBadRequest, Status: "400 Bad Request"},
+		[]byte(`{"statusCode":400,"data":{"errors":[{"message":{"translation":"Title is required"}}]}}`))
+	assert.Equal(t, "Title is required", err.Message())
+	assert.Equal(t, "unknown response status: 400 Bad Request: Title is required", err.Error())
+
+	err = newAPIError(nil, &http.Response{StatusCode: http.StatusBadGateway, Status: "502 Bad Gateway"}, []byte("<html></html>"))
+	assert.Nil(t, err.Response)
+	assert.Equal(t, "", err.Message())
+}
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 )
 
 // NewAPI implements api constructor
@@ -34,6 +35,7 @@ func newAPI(location string, username string, token string) (*api, error) {
 	}
 
 	a.client = &http.Client{Transport: tr}
+	a.retry = DefaultRetryPolicy()
 
 	return a, nil
 }
@@ -50,10 +52,17 @@ func NewAPIWithClient(location string, client *http.Client) (API, error) {
 	a := new(api)
 	a.endPoint = u
 	a.client = client
+	a.retry = DefaultRetryPolicy()
 
 	return a, nil
 }
 
+// baseURL returns the site base the relative links of responses are resolved against,
+// e.g. https://example.atlassian.net/wiki
+func (a *api) baseURL() string {
+	return strings.TrimSuffix(strings.TrimSuffix(a.endPoint.String(), "/"), "/rest/api")
+}
+
 // VerifyTLS to enable disable certificate checks
 func (a *api) VerifyTLS(set bool) {
 	tr := &http.Transport{
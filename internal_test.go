@@ -60,3 +60,10 @@ func Test_api_VerifyTLS(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func Test_api_baseURL(t *testing.T) {
+	a, _ := newAPI("https://test.atlassian.net/wiki/rest/api", "test", "test")
+	assert.Equal(t, "https://test.atlassian.net/wiki", a.baseURL())
+	a, _ = newAPI("https://test.atlassian.net/wiki/rest/api/", "test", "test")
+	assert.Equal(t, "https://test.atlassian.net/wiki", a.baseURL())
+}
@@ -0,0 +1,116 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Label prefixes
+const (
+	LabelPrefixGlobal = "global"
+	LabelPrefixMy     = "my"
+	LabelPrefixTeam   = "team"
+)
+
+// labelForbiddenChars are the characters Confluence does not accept in a label name
+const labelForbiddenChars = ":;,.?&[]()#^*@!"
+
+// ValidateLabel checks a label name against the Confluence label rules
+func ValidateLabel(name string) error {
+	if name == "" {
+		return fmt.Errorf("%w: name empty", ErrInvalidLabel)
+	}
+	if len(name) > 255 {
+		return fmt.Errorf("%w: %q is longer than 255 characters", ErrInvalidLabel, name)
+	}
+	for _, r := range name {
+		if unicode.IsSpace(r) {
+			return fmt.Errorf("%w: %q contains a space", ErrInvalidLabel, name)
+		}
+		if strings.ContainsRune(labelForbiddenChars, r) {
+			return fmt.Errorf("%w: %q contains %q", ErrInvalidLabel, name, r)
+		}
+	}
+	return nil
+}
+
+// GetLabels lists the labels of the given content, following every page.
+// prefix filters the labels by prefix (global, my, team), all labels are returned when empty.
+func (a *api) GetLabels(ctx context.Context, contentID string, prefix string) ([]Label, error) {
+	ep, err := a.getContentGenericEndpoint(contentID, "label")
+	if err != nil {
+		return nil, err
+	}
+	data := url.Values{}
+	if prefix != "" {
+		data.Set("prefix", prefix)
+	}
+	ep.RawQuery = data.Encode()
+
+	var labels []Label
+	if err := a.collectPages(ctx, ep.String(), &labels); err != nil {
+		return nil, err
+	}
+	return labels, nil
+}
+
+// AddLabels adds one or more labels to the given content in a single call and returns
+// all the labels of the content. Labels without prefix are added as global labels.
+func (a *api) AddLabels(ctx context.Context, contentID string, labels ...Label) ([]Label, error) {
+	if len(labels) == 0 {
+		return nil, errors.New("no labels")
+	}
+
+	payload := make([]labelPayload, 0, len(labels))
+	for _, l := range labels {
+		if err := ValidateLabel(l.Name); err != nil {
+			return nil, err
+		}
+		prefix := l.Prefix
+		if prefix == "" {
+			prefix = LabelPrefixGlobal
+		}
+		payload = append(payload, labelPayload{Prefix: prefix, Name: l.Name})
+	}
+
+	ep, err := a.getContentGenericEndpoint(contentID, "label")
+	if err != nil {
+		return nil, err
+	}
+
+	var res LabelArray
+	err = a.sendRequest(ctx, ep, http.MethodPost, payload, &res)
+	if err != nil {
+		return nil, err
+	}
+	return res.Results, nil
+}
+
+// RemoveLabel removes a label from the given content
+func (a *api) RemoveLabel(ctx context.Context, contentID string, name string) error {
+	if err := ValidateLabel(name); err != nil {
+		return err
+	}
+
+	ep, err := a.getContentGenericEndpoint(contentID, "label")
+	if err != nil {
+		return err
+	}
+	ep.RawQuery = url.Values{"name": {name}}.Encode()
+
+	return a.sendRequest(ctx, ep, http.MethodDelete, nil, nil)
+}
+
+// SearchByLabel returns a paginator over the content carrying the given label
+func (a *api) SearchByLabel(ctx context.Context, name string) *SearchPaginator {
+	if err := ValidateLabel(name); err != nil {
+		return &SearchPaginator{p: paginator{err: err}}
+	}
+	return a.PaginateSearch(ctx, SearchContentQuery{Cql: "label = " + strconv.Quote(name)})
+}
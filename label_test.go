@@ -0,0 +1,120 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func labelServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/wiki/rest/api/content/42/label" && r.Method == http.MethodGet:
+			switch {
+			case r.URL.Query().Get("prefix") == "my":
+				w.Write([]byte(`{"results":[{"prefix":"my","name":"todo","id":"3"}],"size":1}`))
+			case r.URL.Query().Get("start") == "":
+				w.Write([]byte(`{"results":[{"prefix":"global","name":"a","id":"1"}],"_links":{"base":"` + server.URL + `/wiki","next":"/rest/api/content/42/label?start=1"}}`))
+			default:
+				w.Write([]byte(`{"results":[{"prefix":"my","name":"todo","id":"3"}]}`))
+			}
+		case r.URL.Path == "/wiki/rest/api/content/42/label" && r.Method == http.MethodPost:
+			var labels []labelPayload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&labels))
+			assert.Equal(t, []labelPayload{{Prefix: "global", Name: "draft"}, {Prefix: "team", Name: "docs"}}, labels)
+			w.Write([]byte(`{"results":[{"prefix":"global","name":"draft","id":"4"},{"prefix":"team","name":"docs","id":"5"}],"size":2}`))
+		case r.URL.Path == "/wiki/rest/api/content/42/label" && r.Method == http.MethodDelete:
+			if r.URL.Query().Get("name") != "draft" {
+				http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/wiki/rest/api/search":
+			assert.Equal(t, `label = "release-notes"`, r.URL.Query().Get("cql"))
+			w.Write([]byte(`{"results":[{"content":{"id":"7","type":"page","status":"current","title":"1.0"},"title":"1.0"},` +
+				`{"content":{"id":"9","type":"blogpost","status":"current","title":"1.1"},"title":"1.1"}],"size":2}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func Test_GetLabels(t *testing.T) {
+	server := labelServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	labels, err := api.GetLabels(context.Background(), "42", "")
+	assert.Nil(t, err)
+	assert.Equal(t, []Label{{Prefix: "global", Name: "a", ID: "1"}, {Prefix: "my", Name: "todo", ID: "3"}}, labels)
+
+	labels, err = api.GetLabels(context.Background(), "42", LabelPrefixMy)
+	assert.Nil(t, err)
+	assert.Len(t, labels, 1)
+
+	_, err = api.GetLabels(context.Background(), "43", "")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func Test_AddAndRemoveLabels(t *testing.T) {
+	server := labelServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	labels, err := api.AddLabels(ctx, "42", Label{Name: "draft"}, Label{Prefix: LabelPrefixTeam, Name: "docs"})
+	assert.Nil(t, err)
+	assert.Len(t, labels, 2)
+
+	_, err = api.AddLabels(ctx, "42", Label{Name: "ok"}, Label{Name: "not ok"})
+	assert.True(t, errors.Is(err, ErrInvalidLabel))
+
+	_, err = api.AddLabels(ctx, "42")
+	assert.Equal(t, "no labels", err.Error())
+
+	assert.Nil(t, api.RemoveLabel(ctx, "42", "draft"))
+	assert.True(t, errors.Is(api.RemoveLabel(ctx, "42", "other"), ErrNotFound))
+	assert.True(t, errors.Is(api.RemoveLabel(ctx, "42", "a,b"), ErrInvalidLabel))
+}
+
+func Test_SearchByLabel(t *testing.T) {
+	server := labelServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	results, err := api.SearchByLabel(context.Background(), "release-notes").All(0)
+	assert.Nil(t, err)
+	assert.Len(t, results, 2)
+	assert.Equal(t, "7", results[0].Content.ID)
+	assert.Equal(t, "page", results[0].Content.Type)
+	assert.Equal(t, "9", results[1].Content.ID)
+	assert.Equal(t, "blogpost", results[1].Content.Type)
+
+	p := api.SearchByLabel(context.Background(), "bad label")
+	assert.False(t, p.Next())
+	assert.True(t, errors.Is(p.Err(), ErrInvalidLabel))
+}
+
+func TestValidateLabel(t *testing.T) {
+	for _, name := range []string{"draft", "release-notes", "v2_final", "ünïcode", strings.Repeat("a", 255)} {
+		assert.Nil(t, ValidateLabel(name), name)
+	}
+	for _, name := range []string{"", "two words", "tab\tbed", "a:b", "a;b", "a,b", "a.b", "a?b", "a&b", "a[b", "a]b", "a(b", "a)b", "a#b", "a^b", "a*b", "a@b", "a!b", strings.Repeat("a", 256)} {
+		assert.True(t, errors.Is(ValidateLabel(name), ErrInvalidLabel), name)
+	}
+	assert.Equal(t, `invalid label: "a:b" contains ':'`, ValidateLabel("a:b").Error())
+}
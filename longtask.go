@@ -0,0 +1,84 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// getLongTaskEndpoint creates the correct api endpoint by given task id
+func (a *api) getLongTaskEndpoint(id string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/longtask/" + id)
+}
+
+// GetTask gets the state of a long running task
+func (a *api) GetTask(ctx context.Context, id string) (*LongTask, error) {
+	ep, err := a.getLongTaskEndpoint(id)
+	if err != nil {
+		return nil, err
+	}
+
+	var task LongTask
+	err = a.sendRequest(ctx, ep, http.MethodGet, nil, &task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// WaitForTask polls a long running task every pollInterval until it finishes,
+// a pollInterval which is not positive defaults to one second.
+// progress, when not nil, is called with the task after every poll.
+// A LongTaskError is returned when the task fails, or when ctx expires before the task finished.
+func (a *api) WaitForTask(ctx context.Context, id string, pollInterval time.Duration, progress func(*LongTask)) (*LongTask, error) {
+	var last *LongTask
+	err := poll(ctx, pollInterval, func() (bool, error) {
+		task, err := a.GetTask(ctx, id)
+		if err != nil {
+			return false, err
+		}
+		last = task
+		if progress != nil {
+			progress(task)
+		}
+		return task.Finished, nil
+	})
+	if err != nil {
+		return nil, taskContextError(ctx, id, last, err)
+	}
+	if !last.Successful {
+		return last, &LongTaskError{ID: id, Task: last, Err: ErrTaskFailed}
+	}
+	return last, nil
+}
+
+// taskContextError turns err into a timeout error when the deadline of ctx was exceeded
+func taskContextError(ctx context.Context, id string, last *LongTask, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return &LongTaskError{ID: id, Task: last, Err: ErrTaskTimeout, Cause: err}
+	}
+	return err
+}
+
+// ErrorMessages returns the translated error messages of the task
+func (t *LongTask) ErrorMessages() []string {
+	messages := make([]string, 0, len(t.Errors))
+	for _, m := range t.Errors {
+		messages = append(messages, m.Translation)
+	}
+	return messages
+}
+
+// message summarizes the errors of the task, or its last message when it reported no error
+func (t *LongTask) message() string {
+	if len(t.Errors) != 0 {
+		return strings.Join(t.ErrorMessages(), "; ")
+	}
+	if n := len(t.Messages); n != 0 {
+		return t.Messages[n-1].Translation
+	}
+	return ""
+}
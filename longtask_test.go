@@ -0,0 +1,73 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_WaitForTask(t *testing.T) {
+	polls := map[string]int{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.URL.Path[len("/wiki/rest/api/longtask/"):]
+		polls[id]++
+		switch {
+		case id == "copy" && polls[id] < 3:
+			w.Write([]byte(`{"id":"copy","percentageComplete":` + []string{"0", "0", "50"}[polls[id]] +
+				`,"finished":false,"messages":[{"translation":"Copying pages"}]}`))
+		case id == "copy":
+			w.Write([]byte(`{"id":"copy","name":{"key":"com.atlassian.confluence.copy.page.hierarchy"},` +
+				`"elapsedTime":1200,"percentageComplete":100,"successful":true,"finished":true,"status":"Completed"}`))
+		case id == "delete":
+			w.Write([]byte(`{"id":"delete","percentageComplete":40,"successful":false,"finished":true,` +
+				`"errors":[{"translation":"Space is locked"},{"translation":"Retry later"}]}`))
+		case id == "slow":
+			w.Write([]byte(`{"id":"slow","percentageComplete":10,"finished":false}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	var progress []int
+	task, err := api.WaitForTask(ctx, "copy", time.Millisecond, func(task *LongTask) {
+		progress = append(progress, task.PercentageComplete)
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []int{0, 50, 100}, progress)
+	assert.True(t, task.Successful)
+	assert.Equal(t, "com.atlassian.confluence.copy.page.hierarchy", task.Name.Key)
+	assert.Equal(t, int64(1200), task.ElapsedTime)
+
+	task, err = api.WaitForTask(ctx, "delete", time.Millisecond, nil)
+	assert.True(t, errors.Is(err, ErrTaskFailed))
+	assert.Equal(t, "long running task failed: delete at 40%: Space is locked; Retry later", err.Error())
+	assert.Equal(t, []string{"Space is locked", "Retry later"}, task.ErrorMessages())
+
+	timeout, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, err = api.WaitForTask(timeout, "slow", 5*time.Millisecond, nil)
+	var taskErr *LongTaskError
+	assert.True(t, errors.As(err, &taskErr))
+	assert.True(t, errors.Is(err, ErrTaskTimeout))
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 10, taskErr.Task.PercentageComplete)
+
+	canceled, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = api.WaitForTask(canceled, "slow", time.Millisecond, nil)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.False(t, errors.Is(err, ErrTaskTimeout))
+
+	_, err = api.GetTask(ctx, "unknown")
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
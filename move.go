@@ -0,0 +1,172 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"net/http"
+	"net/url"
+	"regexp"
+)
+
+// MovePosition is where a page is moved relative to its target
+type MovePosition string
+
+// Move positions
+const (
+	MoveBefore MovePosition = "before" // sibling placed before the target
+	MoveAfter  MovePosition = "after"  // sibling placed after the target
+	MoveAppend MovePosition = "append" // last child of the target
+)
+
+// getContentMoveEndpoint creates the correct api endpoint by given id, position and target id
+func (a *api) getContentMoveEndpoint(id string, position MovePosition, targetID string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/content/" + id + "/move/" + string(position) + "/" + targetID)
+}
+
+// MovePage moves a page and its descendants relative to the target page, possibly into another space.
+// Sites without the move endpoint, or opts.UpdateAncestors, move the page by updating its ancestors:
+// the page then becomes the last child of the target, MoveBefore and MoveAfter cannot be applied
+// and return ErrPositionUnsupported.
+func (a *api) MovePage(ctx context.Context, pageID string, position MovePosition, targetID string, opts MovePageOptions) error {
+	switch position {
+	case MoveBefore, MoveAfter, MoveAppend:
+	default:
+		return fmt.Errorf("invalid move position %q", position)
+	}
+	if opts.UpdateAncestors {
+		return a.movePageByUpdate(ctx, pageID, position, targetID)
+	}
+
+	ep, err := a.getContentMoveEndpoint(pageID, position, targetID)
+	if err != nil {
+		return err
+	}
+
+	err = a.sendRequest(ctx, ep, http.MethodPut, nil, nil)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		return err
+	}
+	switch apiErr.StatusCode {
+	case http.StatusMethodNotAllowed, http.StatusNotImplemented:
+		return a.movePageByUpdate(ctx, pageID, position, targetID)
+	}
+	return err
+}
+
+// movePageByUpdate appends a page to the children of the target by setting its parent
+func (a *api) movePageByUpdate(ctx context.Context, pageID string, position MovePosition, targetID string) error {
+	if position != MoveAppend {
+		return fmt.Errorf("%w: %s", ErrPositionUnsupported, position)
+	}
+
+	target, err := a.GetContentByID(ctx, targetID, ContentByIDQuery{Expand: []string{"space"}})
+	if err != nil {
+		return err
+	}
+
+	_, err = a.UpdateContent(ctx, Results{
+		ID:        pageID,
+		Ancestors: []Results{{ID: target.ID}},
+		Space:     &Space{Key: target.spaceKey()},
+	}, UpdateContentOptions{MinorEdit: true})
+	return err
+}
+
+// Safe reports whether the subtree can be moved without breaking titles or links
+func (c *MoveCheck) Safe() bool {
+	return len(c.TitleCollisions) == 0 && len(c.BrokenLinks) == 0
+}
+
+// pageLinkExp matches the page references of a storage body
+var pageLinkExp = regexp.MustCompile(`<ri:page\s[^>]*>`)
+
+// pageLinkAttrExp matches the attributes of a page reference
+var pageLinkAttrExp = regexp.MustCompile(`(ri:content-title|ri:space-key)="([^"]*)"`)
+
+// MoveSubtreeToSpace moves a page and all its descendants to another space.
+// The move is refused with ErrUnsafeMove when a moved page shares its title with a page
+// of the target space, or when a moved page links by title to a page left behind,
+// unless opts.AllowBrokenLinks is set. The check is returned in every case.
+func (a *api) MoveSubtreeToSpace(ctx context.Context, pageID string, spaceKey string, opts MoveSubtreeOptions) (*MoveCheck, error) {
+	check, err := a.checkSubtreeMove(ctx, pageID, spaceKey)
+	if err != nil {
+		return nil, err
+	}
+	if len(check.TitleCollisions) != 0 || (len(check.BrokenLinks) != 0 && !opts.AllowBrokenLinks) {
+		return check, fmt.Errorf("%w: %d title collisions, %d broken links",
+			ErrUnsafeMove, len(check.TitleCollisions), len(check.BrokenLinks))
+	}
+	if opts.DryRun {
+		return check, nil
+	}
+
+	parentID := opts.ParentID
+	if parentID == "" {
+		space, err := a.GetSpace(ctx, spaceKey, []string{"homepage"})
+		if err != nil {
+			return check, err
+		}
+		if space.Homepage == nil || space.Homepage.ID == "" {
+			return check, fmt.Errorf("space %s has no homepage", spaceKey)
+		}
+		parentID = space.Homepage.ID
+	}
+	return check, a.MovePage(ctx, pageID, MoveAppend, parentID, MovePageOptions{UpdateAncestors: opts.UpdateAncestors})
+}
+
+// checkSubtreeMove reads the subtree below pageID and checks it against the target space
+func (a *api) checkSubtreeMove(ctx context.Context, pageID string, spaceKey string) (*MoveCheck, error) {
+	root, err := a.GetContentByID(ctx, pageID, ContentByIDQuery{Expand: []string{"body.storage"}})
+	if err != nil {
+		return nil, err
+	}
+	descendants, err := a.PaginateDescendantPages(ctx, pageID, []string{"body.storage"}).All(0)
+	if err != nil {
+		return nil, err
+	}
+
+	check := &MoveCheck{Pages: append([]Results{*root}, descendants...)}
+	moved := make(map[string]bool, len(check.Pages))
+	for _, p := range check.Pages {
+		moved[p.Title] = true
+	}
+
+	for _, p := range check.Pages {
+		existing, err := a.pagesTitled(ctx, spaceKey, p.Title)
+		if err != nil {
+			return nil, err
+		}
+		check.TitleCollisions = append(check.TitleCollisions, existing...)
+
+		storage, _ := p.Body.Representation(RepresentationStorage)
+		for _, title := range spaceRelativeLinks(storage.Value) {
+			if !moved[title] {
+				check.BrokenLinks = append(check.BrokenLinks, BrokenLink{PageID: p.ID, Title: title})
+			}
+		}
+	}
+	return check, nil
+}
+
+// spaceRelativeLinks returns the titles of the pages linked by a storage body without naming
+// their space, those links resolve in the space of the linking page
+func spaceRelativeLinks(storage string) []string {
+	var titles []string
+	for _, link := range pageLinkExp.FindAllString(storage, -1) {
+		var title, key string
+		for _, attr := range pageLinkAttrExp.FindAllStringSubmatch(link, -1) {
+			if attr[1] == "ri:content-title" {
+				title = html.UnescapeString(attr[2])
+			} else {
+				key = attr[2]
+			}
+		}
+		if title != "" && key == "" {
+			titles = append(titles, title)
+		}
+	}
+	return titles
+}
@@ -0,0 +1,160 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_MovePage(t *testing.T) {
+	var moved []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		moved = append(moved, r.URL.Path)
+		w.Write([]byte(`{"pageId":"5"}`))
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	assert.Nil(t, api.MovePage(ctx, "5", MoveBefore, "3", MovePageOptions{}))
+	assert.Nil(t, api.MovePage(ctx, "5", MoveAppend, "1", MovePageOptions{}))
+	assert.Equal(t, []string{"/wiki/rest/api/content/5/move/before/3", "/wiki/rest/api/content/5/move/append/1"}, moved)
+
+	err = api.MovePage(ctx, "5", MovePosition("below"), "3", MovePageOptions{})
+	assert.Equal(t, `invalid move position "below"`, err.Error())
+	assert.Len(t, moved, 2)
+}
+
+func Test_MovePageFallback(t *testing.T) {
+	var payload contentPayload
+	moveStatus := http.StatusMethodNotAllowed
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPut && r.URL.Path == "/wiki/rest/api/content/5":
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&payload))
+			w.Write([]byte(`{"id":"5","type":"page","title":"Moved"}`))
+		case r.Method == http.MethodPut:
+			http.Error(w, `{"statusCode":0}`, moveStatus)
+		case r.URL.Path == "/wiki/rest/api/content/3":
+			assert.Equal(t, "space", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"id":"3","type":"page","space":{"key":"ENG"}}`))
+		case r.URL.Path == "/wiki/rest/api/content/5":
+			w.Write([]byte(`{"id":"5","type":"page","title":"Moved","version":{"number":3}}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	assert.Nil(t, api.MovePage(ctx, "5", MoveAppend, "3", MovePageOptions{}))
+	assert.Equal(t, []idPayload{{ID: "3"}}, payload.Ancestors)
+	assert.Equal(t, &spacePayload{Key: "ENG"}, payload.Space)
+	assert.Equal(t, 4, payload.Version.Number)
+	assert.True(t, payload.Version.MinorEdit)
+
+	// the order among siblings cannot be set through the ancestors
+	payload = contentPayload{}
+	err = api.MovePage(ctx, "5", MoveAfter, "3", MovePageOptions{})
+	assert.True(t, errors.Is(err, ErrPositionUnsupported))
+	assert.Equal(t, "move position cannot be applied without the move endpoint: after", err.Error())
+	assert.Nil(t, payload.Ancestors)
+
+	moveStatus = http.StatusNotImplemented
+	assert.Nil(t, api.MovePage(ctx, "5", MoveAppend, "3", MovePageOptions{}))
+	assert.Equal(t, []idPayload{{ID: "3"}}, payload.Ancestors)
+
+	// a missing page is reported, not worked around
+	moveStatus = http.StatusNotFound
+	payload = contentPayload{}
+	err = api.MovePage(ctx, "404", MoveBefore, "3", MovePageOptions{})
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, payload.Ancestors)
+
+	// the fallback can be asked for
+	assert.Nil(t, api.MovePage(ctx, "5", MoveAppend, "3", MovePageOptions{UpdateAncestors: true}))
+	assert.Equal(t, []idPayload{{ID: "3"}}, payload.Ancestors)
+	err = api.MovePage(ctx, "5", MoveBefore, "3", MovePageOptions{UpdateAncestors: true})
+	assert.True(t, errors.Is(err, ErrPositionUnsupported))
+}
+
+func Test_MoveSubtreeToSpace(t *testing.T) {
+	var moved string
+	collision := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/rest/api/content/10":
+			assert.Equal(t, "body.storage", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"id":"10","type":"page","title":"Guide","body":{"storage":{"value":` +
+				`"<ac:link><ri:page ri:content-title=\"Setup &amp; Install\" /></ac:link>` +
+				`<ac:link><ri:page ri:space-key=\"DOC\" ri:content-title=\"Pinned\" /></ac:link>` +
+				`<ac:link><ri:page ri:content-title=\"Elsewhere\" /></ac:link>","representation":"storage"}}}`))
+		case "/wiki/rest/api/content/10/descendant/page":
+			w.Write([]byte(`{"results":[{"id":"11","type":"page","title":"Setup & Install","body":{"storage":{"value":"<p>plain</p>"}}}]}`))
+		case "/wiki/rest/api/content/":
+			assert.Equal(t, "ENG", r.URL.Query().Get("spaceKey"))
+			if collision && r.URL.Query().Get("title") == "Guide" {
+				w.Write([]byte(`{"results":[{"id":"77","type":"page","title":"Guide"}]}`))
+				return
+			}
+			w.Write([]byte(`{"results":[]}`))
+		case "/wiki/rest/api/space/ENG":
+			w.Write([]byte(`{"key":"ENG","homepage":{"id":"99"}}`))
+		default:
+			assert.Equal(t, http.MethodPut, r.Method)
+			moved = r.URL.Path
+			w.Write([]byte(`{"pageId":"10"}`))
+		}
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	check, err := api.MoveSubtreeToSpace(ctx, "10", "ENG", MoveSubtreeOptions{})
+	assert.True(t, errors.Is(err, ErrUnsafeMove))
+	assert.Equal(t, "unsafe move: 0 title collisions, 1 broken links", err.Error())
+	assert.Equal(t, []string{"10", "11"}, resultIDs(check.Pages))
+	assert.Equal(t, []BrokenLink{{PageID: "10", Title: "Elsewhere"}}, check.BrokenLinks)
+	assert.False(t, check.Safe())
+	assert.Equal(t, "", moved)
+
+	_, err = api.MoveSubtreeToSpace(ctx, "10", "ENG", MoveSubtreeOptions{AllowBrokenLinks: true, DryRun: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "", moved)
+
+	_, err = api.MoveSubtreeToSpace(ctx, "10", "ENG", MoveSubtreeOptions{AllowBrokenLinks: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "/wiki/rest/api/content/10/move/append/99", moved)
+
+	_, err = api.MoveSubtreeToSpace(ctx, "10", "ENG", MoveSubtreeOptions{AllowBrokenLinks: true, ParentID: "42"})
+	assert.Nil(t, err)
+	assert.Equal(t, "/wiki/rest/api/content/10/move/append/42", moved)
+
+	collision = true
+	check, err = api.MoveSubtreeToSpace(ctx, "10", "ENG", MoveSubtreeOptions{AllowBrokenLinks: true})
+	assert.True(t, errors.Is(err, ErrUnsafeMove))
+	assert.Equal(t, []string{"77"}, resultIDs(check.TitleCollisions))
+}
+
+func TestSpaceRelativeLinks(t *testing.T) {
+	storage := `<ac:link><ri:page ri:content-title="A &amp; B" /></ac:link>` +
+		`<ac:link><ri:page ri:space-key="X" ri:content-title="C" /></ac:link>` +
+		`<ri:attachment ri:filename="d.png" />` +
+		`<ac:link><ri:page ri:content-title="E"></ri:page></ac:link>`
+
+	assert.Equal(t, []string{"A & B", "E"}, spaceRelativeLinks(storage))
+	assert.Nil(t, spaceRelativeLinks("<p>no links</p>"))
+}
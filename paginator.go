@@ -0,0 +1,238 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+)
+
+// paginator follows the _links.next of a collection one page at a time.
+// It holds the iteration state shared by the typed paginators.
+type paginator struct {
+	ctx     context.Context
+	a       *api
+	first   string // url of the first page
+	base    string // base the relative next links are resolved against
+	next    string
+	started bool
+	err     error
+	// decode unmarshals a page and returns its links
+	decode func([]byte) (Links, error)
+}
+
+func newPaginator(ctx context.Context, a *api, first string, base string, decode func([]byte) (Links, error)) paginator {
+	return paginator{ctx: ctx, a: a, first: first, base: base, decode: decode}
+}
+
+// fetch retrieves the next page, it returns false when there are no more pages or on error
+func (p *paginator) fetch() bool {
+	if p.err != nil || (p.started && p.next == "") {
+		return false
+	}
+	if err := p.ctx.Err(); err != nil {
+		p.err = err
+		return false
+	}
+
+	u := p.first
+	if p.started {
+		u = p.next
+		if !strings.HasPrefix(u, "http://") && !strings.HasPrefix(u, "https://") {
+			u = p.base + u
+		}
+	}
+
+	req, err := http.NewRequestWithContext(p.ctx, http.MethodGet, u, nil)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	res, err := p.a.Request(req)
+	if err != nil {
+		p.err = err
+		return false
+	}
+	links, err := p.decode(res)
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.started = true
+	if links.Base != "" {
+		p.base = links.Base
+	}
+	p.next = links.Next
+	return true
+}
+
+// all appends the results of the remaining pages to out, a pointer to a slice,
+// taking them from results after every page. When max is greater than zero
+// at most max results are kept.
+func (p *paginator) all(max int, out interface{}, results func() interface{}) error {
+	items := reflect.ValueOf(out).Elem()
+	for (max <= 0 || items.Len() < max) && p.fetch() {
+		items.Set(reflect.AppendSlice(items, reflect.ValueOf(results())))
+	}
+	if p.err != nil {
+		return p.err
+	}
+	if max > 0 && items.Len() > max {
+		items.Set(items.Slice(0, max))
+	}
+	return nil
+}
+
+// collectPages reads every page of the collection starting at first and appends
+// their results to out, a pointer to a slice of the result type
+func (a *api) collectPages(ctx context.Context, first string, out interface{}) error {
+	items := reflect.ValueOf(out).Elem()
+	p := newPaginator(ctx, a, first, a.baseURL(), func(b []byte) (Links, error) {
+		var page struct {
+			Results json.RawMessage `json:"results"`
+			Links   Links           `json:"_links"`
+		}
+		if err := json.Unmarshal(b, &page); err != nil {
+			return Links{}, err
+		}
+		results := reflect.New(items.Type())
+		if len(page.Results) != 0 {
+			if err := json.Unmarshal(page.Results, results.Interface()); err != nil {
+				return Links{}, err
+			}
+		}
+		items.Set(reflect.AppendSlice(items, results.Elem()))
+		return page.Links, nil
+	})
+	for p.fetch() {
+	}
+	return p.err
+}
+
+// ContentPaginator iterates over the pages of a content collection
+type ContentPaginator struct {
+	p    paginator
+	page *Content
+}
+
+// PaginateContent returns a paginator over the content matching query
+func (a *api) PaginateContent(ctx context.Context, query ContentQuery) *ContentPaginator {
+	c := &ContentPaginator{}
+	ep, err := a.getContentEndpoint()
+	if err != nil {
+		c.p.err = err
+		return c
+	}
+	ep.RawQuery = addContentQueryParams(query).Encode()
+	c.p = newPaginator(ctx, a, ep.String(), a.baseURL(), c.decode)
+	return c
+}
+
+// PaginateLinks returns a paginator over any content collection starting at links.Next,
+// e.g. the children of a page or the attachments of a result
+func (a *api) PaginateLinks(ctx context.Context, links Links) *ContentPaginator {
+	c := &ContentPaginator{}
+	base := links.Base
+	if base == "" {
+		base = a.baseURL()
+	}
+	c.p = newPaginator(ctx, a, "", base, c.decode)
+	c.p.started, c.p.next = true, links.Next
+	return c
+}
+
+// newContentPaginator returns a paginator starting at the given url
+func (a *api) newContentPaginator(ctx context.Context, first string) *ContentPaginator {
+	c := &ContentPaginator{}
+	c.p = newPaginator(ctx, a, first, a.baseURL(), c.decode)
+	return c
+}
+
+func (c *ContentPaginator) decode(b []byte) (Links, error) {
+	var content Content
+	if err := json.Unmarshal(b, &content); err != nil {
+		return Links{}, err
+	}
+	c.page = &content
+	return content.Links, nil
+}
+
+// Next fetches the next page, it returns false once all pages were read or an error occurred
+func (c *ContentPaginator) Next() bool {
+	return c.p.fetch()
+}
+
+// Page returns the current page
+func (c *ContentPaginator) Page() *Content {
+	return c.page
+}
+
+// Err returns the error which stopped the iteration
+func (c *ContentPaginator) Err() error {
+	return c.p.err
+}
+
+// All reads the remaining pages and returns their results.
+// When max is greater than zero at most max results are returned.
+func (c *ContentPaginator) All(max int) ([]Results, error) {
+	var results []Results
+	if err := c.p.all(max, &results, func() interface{} { return c.page.Results }); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// SearchPaginator iterates over the pages of a search
+type SearchPaginator struct {
+	p    paginator
+	page *SearchPageResults
+}
+
+// PaginateSearch returns a paginator over the results of a search
+func (a *api) PaginateSearch(ctx context.Context, query SearchContentQuery) *SearchPaginator {
+	s := &SearchPaginator{}
+	ep, err := a.getSearchEndpoint()
+	if err != nil {
+		s.p.err = err
+		return s
+	}
+	ep.RawQuery = addSearchQueryParams(query).Encode()
+	s.p = newPaginator(ctx, a, ep.String(), a.baseURL(), s.decode)
+	return s
+}
+
+func (s *SearchPaginator) decode(b []byte) (Links, error) {
+	var page SearchPageResults
+	if err := json.Unmarshal(b, &page); err != nil {
+		return Links{}, err
+	}
+	s.page = &page
+	return page.Links, nil
+}
+
+// Next fetches the next page, it returns false once all pages were read or an error occurred
+func (s *SearchPaginator) Next() bool {
+	return s.p.fetch()
+}
+
+// Page returns the current page
+func (s *SearchPaginator) Page() *SearchPageResults {
+	return s.page
+}
+
+// Err returns the error which stopped the iteration
+func (s *SearchPaginator) Err() error {
+	return s.p.err
+}
+
+// All reads the remaining pages and returns their results.
+// When max is greater than zero at most max results are returned.
+func (s *SearchPaginator) All(max int) ([]SearchPageResult, error) {
+	var results []SearchPageResult
+	if err := s.p.all(max, &results, func() interface{} { return s.page.Results }); err != nil {
+		return nil, err
+	}
+	return results, nil
+}
@@ -0,0 +1,154 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// pagedServer serves three content pages of two results and two search pages of two results
+func pagedServer() *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var resp interface{}
+		switch r.URL.Path {
+		case "/wiki/rest/api/content/", "/wiki/rest/api/content/1/child/page":
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			page := Content{Start: start, Limit: 2, Size: 2, Links: Links{Base: server.URL + "/wiki"}}
+			for i := start; i < start+2; i++ {
+				page.Results = append(page.Results, Results{ID: strconv.Itoa(i)})
+			}
+			if start < 4 {
+				page.Links.Next = fmt.Sprintf("%s?start=%d", r.URL.Path[len("/wiki"):], start+2)
+			}
+			resp = page
+		case "/wiki/rest/api/search":
+			page := SearchPageResults{Links: Links{Base: server.URL + "/wiki"}}
+			if r.URL.Query().Get("cursor") == "" {
+				page.Results = []SearchPageResult{{Title: "a", Content: Results{ID: "a"}}, {Title: "b", Content: Results{ID: "b"}}}
+				page.Links.Next = "/rest/api/search?next=true&cursor=abc"
+			} else {
+				page.Results = []SearchPageResult{{Title: "c", Content: Results{ID: "c"}}, {Title: "d", Content: Results{ID: "d"}}}
+			}
+			resp = page
+		default:
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		b, _ := json.Marshal(resp)
+		w.Write(b)
+	}))
+	return server
+}
+
+func TestContentPaginator(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	p := api.PaginateContent(context.Background(), ContentQuery{Limit: 2})
+	var starts []int
+	for p.Next() {
+		starts = append(starts, p.Page().Start)
+	}
+	assert.Nil(t, p.Err())
+	assert.Equal(t, []int{0, 2, 4}, starts)
+	assert.False(t, p.Next())
+}
+
+func TestContentPaginator_All(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	r, err := api.PaginateContent(context.Background(), ContentQuery{}).All(0)
+	assert.Nil(t, err)
+	assert.Len(t, r, 6)
+	assert.Equal(t, "5", r[5].ID)
+
+	r, err = api.PaginateContent(context.Background(), ContentQuery{}).All(3)
+	assert.Nil(t, err)
+	assert.Equal(t, []Results{{ID: "0"}, {ID: "1"}, {ID: "2"}}, r)
+}
+
+func TestPaginateLinks(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	r, err := api.PaginateLinks(context.Background(), Links{Next: "/rest/api/content/1/child/page?start=2"}).All(0)
+	assert.Nil(t, err)
+	assert.Len(t, r, 4)
+
+	p := api.PaginateLinks(context.Background(), Links{})
+	assert.False(t, p.Next())
+	assert.Nil(t, p.Err())
+}
+
+func TestContentPaginator_Errors(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	p := api.PaginateLinks(context.Background(), Links{Next: "/rest/api/missing"})
+	r, err := p.All(0)
+	assert.Nil(t, r)
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	p = api.PaginateContent(ctx, ContentQuery{})
+	assert.True(t, p.Next())
+	cancel()
+	assert.False(t, p.Next())
+	assert.Equal(t, context.Canceled, p.Err())
+}
+
+func TestSearchPaginator(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	r, err := api.PaginateSearch(context.Background(), SearchContentQuery{Cql: "type=page"}).All(0)
+	assert.Nil(t, err)
+	assert.Len(t, r, 4)
+	assert.Equal(t, "d", r[3].Title)
+
+	r, err = api.PaginateSearch(context.Background(), SearchContentQuery{Cql: "type=page"}).All(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []SearchPageResult{{Title: "a", Content: Results{ID: "a"}}}, r)
+}
+
+func TestCollectPages(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	var results []Results
+	err = api.collectPages(context.Background(), server.URL+"/wiki/rest/api/content/?start=0", &results)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"0", "1", "2", "3", "4", "5"}, resultIDs(results))
+
+	var missing []Results
+	err = api.collectPages(context.Background(), server.URL+"/wiki/rest/api/missing", &missing)
+	assert.True(t, errors.Is(err, ErrNotFound))
+	assert.Nil(t, missing)
+}
@@ -0,0 +1,173 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+)
+
+// GetContentProperties lists the properties of the given content, following every page
+func (a *api) GetContentProperties(ctx context.Context, contentID string) ([]ContentProperty, error) {
+	ep, err := a.getContentGenericEndpoint(contentID, "property")
+	if err != nil {
+		return nil, err
+	}
+	ep.RawQuery = "expand=version"
+
+	var properties []ContentProperty
+	if err := a.collectPages(ctx, ep.String(), &properties); err != nil {
+		return nil, err
+	}
+	return properties, nil
+}
+
+// getContentPropertyEndpoint creates the correct api endpoint by given id and property key
+func (a *api) getContentPropertyEndpoint(id string, key string) (*url.URL, error) {
+	return a.getContentGenericEndpoint(id, "property/"+url.PathEscape(key))
+}
+
+// GetContentProperty gets a property of the given content by key
+func (a *api) GetContentProperty(ctx context.Context, contentID string, key string) (*ContentProperty, error) {
+	ep, err := a.getContentPropertyEndpoint(contentID, key)
+	if err != nil {
+		return nil, err
+	}
+	ep.RawQuery = "expand=version"
+
+	var property ContentProperty
+	err = a.sendRequest(ctx, ep, http.MethodGet, nil, &property)
+	if err != nil {
+		return nil, err
+	}
+	return &property, nil
+}
+
+// CreateContentProperty creates a property of the given content, value is marshalled as JSON
+func (a *api) CreateContentProperty(ctx context.Context, contentID string, key string, value interface{}) (*ContentProperty, error) {
+	if key == "" {
+		return nil, errors.New("key empty")
+	}
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	ep, err := a.getContentGenericEndpoint(contentID, "property")
+	if err != nil {
+		return nil, err
+	}
+
+	var property ContentProperty
+	err = a.sendRequest(ctx, ep, http.MethodPost, propertyPayload{Key: key, Value: raw}, &property)
+	if err != nil {
+		return nil, err
+	}
+	return &property, nil
+}
+
+// UpdateContentProperty saves p as the next version of the property.
+// p.Version.Number is the version being edited, the current version is fetched when it is not set.
+func (a *api) UpdateContentProperty(ctx context.Context, contentID string, p ContentProperty, opts UpdatePropertyOptions) (*ContentProperty, error) {
+	if p.Key == "" {
+		return nil, errors.New("key empty")
+	}
+	if p.Version.Number == 0 {
+		current, err := a.GetContentProperty(ctx, contentID, p.Key)
+		if err != nil {
+			return nil, err
+		}
+		p.Version.Number = current.Version.Number
+	}
+
+	ep, err := a.getContentPropertyEndpoint(contentID, p.Key)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := propertyPayload{
+		Key:   p.Key,
+		Value: p.Value,
+		Version: &versionPayload{
+			Number:    p.Version.Number + 1,
+			MinorEdit: opts.MinorEdit,
+		},
+	}
+
+	var property ContentProperty
+	err = a.sendRequest(ctx, ep, http.MethodPut, payload, &property)
+	if err != nil {
+		return nil, err
+	}
+	return &property, nil
+}
+
+// DeleteContentProperty deletes a property of the given content
+func (a *api) DeleteContentProperty(ctx context.Context, contentID string, key string) error {
+	ep, err := a.getContentPropertyEndpoint(contentID, key)
+	if err != nil {
+		return err
+	}
+	return a.sendRequest(ctx, ep, http.MethodDelete, nil, nil)
+}
+
+// GetContentPropertyValue unmarshals the value of a property into v and returns the property version
+func (a *api) GetContentPropertyValue(ctx context.Context, contentID string, key string, v interface{}) (int, error) {
+	p, err := a.GetContentProperty(ctx, contentID, key)
+	if err != nil {
+		return 0, err
+	}
+	if err := json.Unmarshal(p.Value, v); err != nil {
+		return 0, err
+	}
+	return p.Version.Number, nil
+}
+
+// SetContentPropertyValue marshals v as the value of a property, creating the property when it does not exist.
+// When the update conflicts with a concurrent one it is retried up to opts.ConflictRetries times.
+func (a *api) SetContentPropertyValue(ctx context.Context, contentID string, key string, v interface{}, opts UpdatePropertyFuncOptions) (*ContentProperty, error) {
+	return a.UpdateContentPropertyFunc(ctx, contentID, key, nil, func() (interface{}, error) {
+		return v, nil
+	}, opts)
+}
+
+// UpdateContentPropertyFunc unmarshals the current value of a property into v, when v is not nil,
+// calls fn and saves the value it returns as the next version. A missing property is created.
+// When the update conflicts with a concurrent one the property is read and fn called again,
+// up to opts.ConflictRetries times.
+func (a *api) UpdateContentPropertyFunc(ctx context.Context, contentID string, key string, v interface{}, fn func() (interface{}, error), opts UpdatePropertyFuncOptions) (*ContentProperty, error) {
+	var updated *ContentProperty
+	err := retryOnConflict(ctx, opts.ConflictRetries, func() error {
+		current, err := a.GetContentProperty(ctx, contentID, key)
+		if err != nil && !errors.Is(err, ErrNotFound) {
+			return err
+		}
+		if current != nil && v != nil {
+			if err := json.Unmarshal(current.Value, v); err != nil {
+				return err
+			}
+		}
+
+		value, err := fn()
+		if err != nil {
+			return err
+		}
+
+		if current == nil {
+			updated, err = a.CreateContentProperty(ctx, contentID, key, value)
+			return err
+		}
+		raw, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		current.Value = raw
+		updated, err = a.UpdateContentProperty(ctx, contentID, *current, opts.UpdatePropertyOptions)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return updated, nil
+}
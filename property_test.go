@@ -0,0 +1,201 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type buildInfo struct {
+	Commit string `json:"commit"`
+	Runs   int    `json:"runs"`
+}
+
+// propertyServer stores the properties of content 42 in memory.
+// The first `conflicts` updates are rejected after a concurrent update bumped the version.
+func propertyServer(t *testing.T, conflicts int) *httptest.Server {
+	properties := map[string]*ContentProperty{}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		const prefix = "/wiki/rest/api/content/42/property"
+		if !strings.HasPrefix(r.URL.Path, prefix) {
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+			return
+		}
+		key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+		write := func(v interface{}) {
+			b, _ := json.Marshal(v)
+			w.Write(b)
+		}
+
+		switch {
+		case key == "" && r.Method == http.MethodGet:
+			var list []ContentProperty
+			for _, p := range properties {
+				list = append(list, *p)
+			}
+			write(map[string]interface{}{"results": list})
+		case key == "" && r.Method == http.MethodPost:
+			var p propertyPayload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+			properties[p.Key] = &ContentProperty{ID: strconv.Itoa(len(properties) + 1), Key: p.Key, Value: p.Value, Version: Version{Number: 1}}
+			write(properties[p.Key])
+		case properties[key] == nil:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		case r.Method == http.MethodGet:
+			write(properties[key])
+		case r.Method == http.MethodPut:
+			var p propertyPayload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+			current := properties[key]
+			if conflicts > 0 {
+				conflicts--
+				current.Version.Number++
+			}
+			if p.Version.Number != current.Version.Number+1 {
+				http.Error(w, `{"statusCode":409}`, http.StatusConflict)
+				return
+			}
+			current.Value = p.Value
+			current.Version.Number = p.Version.Number
+			write(current)
+		case r.Method == http.MethodDelete:
+			delete(properties, key)
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+}
+
+func Test_ContentPropertyCRUD(t *testing.T) {
+	server := propertyServer(t, 0)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	p, err := api.CreateContentProperty(ctx, "42", "build", buildInfo{Commit: "abc"})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, p.Version.Number)
+	assert.JSONEq(t, `{"commit":"abc","runs":0}`, string(p.Value))
+
+	p, err = api.GetContentProperty(ctx, "42", "build")
+	assert.Nil(t, err)
+	assert.Equal(t, "build", p.Key)
+
+	p.Value = json.RawMessage(`{"commit":"def","runs":1}`)
+	p, err = api.UpdateContentProperty(ctx, "42", *p, UpdatePropertyOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, p.Version.Number)
+
+	p, err = api.UpdateContentProperty(ctx, "42", ContentProperty{Key: "build", Value: json.RawMessage(`{}`)}, UpdatePropertyOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, p.Version.Number)
+
+	list, err := api.GetContentProperties(ctx, "42")
+	assert.Nil(t, err)
+	assert.Len(t, list, 1)
+
+	assert.Nil(t, api.DeleteContentProperty(ctx, "42", "build"))
+	_, err = api.GetContentProperty(ctx, "42", "build")
+	assert.True(t, errors.Is(err, ErrNotFound))
+
+	_, err = api.CreateContentProperty(ctx, "42", "", nil)
+	assert.Equal(t, "key empty", err.Error())
+}
+
+func Test_ContentPropertyKeyEscaping(t *testing.T) {
+	server := propertyServer(t, 0)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	// a plain "a" must not be read or updated in place of the escaped keys
+	_, err = api.CreateContentProperty(ctx, "42", "a", "plain")
+	assert.Nil(t, err)
+	for _, key := range []string{"a?b", "a/b", "a b#c"} {
+		_, err = api.CreateContentProperty(ctx, "42", key, key)
+		assert.Nil(t, err)
+
+		p, err := api.GetContentProperty(ctx, "42", key)
+		assert.Nil(t, err)
+		assert.Equal(t, key, p.Key)
+
+		p, err = api.UpdateContentProperty(ctx, "42", ContentProperty{Key: key, Value: json.RawMessage(`"updated"`)}, UpdatePropertyOptions{})
+		assert.Nil(t, err)
+		assert.Equal(t, 2, p.Version.Number)
+
+		assert.Nil(t, api.DeleteContentProperty(ctx, "42", key))
+	}
+
+	p, err := api.GetContentProperty(ctx, "42", "a")
+	assert.Nil(t, err)
+	assert.Equal(t, 1, p.Version.Number)
+	assert.JSONEq(t, `"plain"`, string(p.Value))
+
+	a, err := newAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ep, err := a.getContentPropertyEndpoint("42", "a?b/c")
+	assert.Nil(t, err)
+	assert.Equal(t, "/wiki/rest/api/content/42/property/a%3Fb%2Fc", ep.EscapedPath())
+	assert.Equal(t, "", ep.RawQuery)
+}
+
+func Test_ContentPropertyValue(t *testing.T) {
+	server := propertyServer(t, 0)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	p, err := api.SetContentPropertyValue(ctx, "42", "build", buildInfo{Commit: "abc", Runs: 1}, UpdatePropertyFuncOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 1, p.Version.Number)
+
+	p, err = api.SetContentPropertyValue(ctx, "42", "build", buildInfo{Commit: "def", Runs: 2}, UpdatePropertyFuncOptions{})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, p.Version.Number)
+
+	var info buildInfo
+	version, err := api.GetContentPropertyValue(ctx, "42", "build", &info)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, version)
+	assert.Equal(t, buildInfo{Commit: "def", Runs: 2}, info)
+}
+
+func Test_UpdateContentPropertyFunc(t *testing.T) {
+	server := propertyServer(t, 2)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	var info buildInfo
+	increment := func() (interface{}, error) {
+		info.Runs++
+		return info, nil
+	}
+
+	_, err = api.UpdateContentPropertyFunc(ctx, "42", "build", &info, increment, UpdatePropertyFuncOptions{})
+	assert.Nil(t, err)
+
+	info = buildInfo{}
+	_, err = api.UpdateContentPropertyFunc(ctx, "42", "build", &info, increment, UpdatePropertyFuncOptions{ConflictRetries: 1})
+	assert.True(t, errors.Is(err, ErrConflict))
+
+	info = buildInfo{}
+	p, err := api.UpdateContentPropertyFunc(ctx, "42", "build", &info, increment, UpdatePropertyFuncOptions{ConflictRetries: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, 4, p.Version.Number)
+	assert.JSONEq(t, `{"commit":"","runs":2}`, string(p.Value))
+}
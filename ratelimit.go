@@ -0,0 +1,201 @@
+package confluentcloud
+
+import (
+	"context"
+	"io"
+	"math"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// RateLimit configures the client side throttling shared by every request of an api instance
+type RateLimit struct {
+	RequestsPerSecond    float64 // steady request rate, 0 disables the token bucket
+	Burst                int     // requests allowed at once before throttling kicks in
+	MaxInFlight          int     // maximum concurrent requests, 0 means unlimited
+	MinRequestsPerSecond float64 // lower bound of the rate when adapting to 429 responses
+}
+
+// LimiterStats is a snapshot of the rate limiter state
+type LimiterStats struct {
+	RequestsPerSecond float64       // current, possibly adapted, request rate
+	InFlight          int           // requests currently being sent or read
+	Waiting           int           // requests currently waiting for a slot or token
+	Waits             int64         // requests which had to wait
+	TotalWait         time.Duration // accumulated wait time
+	Throttled         int64         // 429 responses observed
+}
+
+// rateLimiter is a token bucket combined with a max-in-flight semaphore.
+// The rate is halved whenever Confluence answers 429 and recovers
+// gradually on successful responses.
+type rateLimiter struct {
+	mu      sync.Mutex
+	rate    float64
+	maxRate float64
+	minRate float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+	sem     chan struct{}
+	stats   LimiterStats
+}
+
+// SetRateLimit sets the client side rate limit, nil disables it
+func (a *api) SetRateLimit(l *RateLimit) {
+	var limiter *rateLimiter
+	if l != nil {
+		limiter = newRateLimiter(*l)
+	}
+	a.mu.Lock()
+	a.limiter = limiter
+	a.mu.Unlock()
+}
+
+// RateLimiterStats returns the current rate limiter statistics
+func (a *api) RateLimiterStats() LimiterStats {
+	_, limiter := a.policies()
+	return limiter.Stats()
+}
+
+func newRateLimiter(l RateLimit) *rateLimiter {
+	r := &rateLimiter{
+		rate:    l.RequestsPerSecond,
+		maxRate: l.RequestsPerSecond,
+		minRate: l.MinRequestsPerSecond,
+		burst:   float64(l.Burst),
+		last:    time.Now(),
+	}
+	if r.burst < 1 {
+		r.burst = 1
+	}
+	if r.minRate <= 0 || r.minRate > r.maxRate {
+		r.minRate = r.maxRate / 10
+	}
+	r.tokens = r.burst
+	r.stats.RequestsPerSecond = r.rate
+	if l.MaxInFlight > 0 {
+		r.sem = make(chan struct{}, l.MaxInFlight)
+	}
+	return r
+}
+
+// acquire blocks until a request may be sent and returns the function releasing its slot
+func (r *rateLimiter) acquire(ctx context.Context) (func(), error) {
+	if r == nil {
+		return func() {}, nil
+	}
+	start := time.Now()
+	r.mu.Lock()
+	r.stats.Waiting++
+	r.mu.Unlock()
+	defer func() {
+		r.mu.Lock()
+		r.stats.Waiting--
+		if waited := time.Since(start); waited > time.Millisecond {
+			r.stats.Waits++
+			r.stats.TotalWait += waited
+		}
+		r.mu.Unlock()
+	}()
+
+	if r.sem != nil {
+		select {
+		case r.sem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	if err := r.take(ctx); err != nil {
+		if r.sem != nil {
+			<-r.sem
+		}
+		return nil, err
+	}
+
+	r.mu.Lock()
+	r.stats.InFlight++
+	r.mu.Unlock()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			r.mu.Lock()
+			r.stats.InFlight--
+			r.mu.Unlock()
+			if r.sem != nil {
+				<-r.sem
+			}
+		})
+	}, nil
+}
+
+// take reserves a token, waiting until it becomes available
+func (r *rateLimiter) take(ctx context.Context) error {
+	r.mu.Lock()
+	if r.rate <= 0 {
+		r.mu.Unlock()
+		return nil
+	}
+	now := time.Now()
+	r.tokens = math.Min(r.burst, r.tokens+now.Sub(r.last).Seconds()*r.rate)
+	r.last = now
+	r.tokens--
+	var wait time.Duration
+	if r.tokens < 0 {
+		wait = time.Duration(-r.tokens / r.rate * float64(time.Second))
+	}
+	r.mu.Unlock()
+
+	if wait == 0 {
+		return nil
+	}
+	if err := sleep(ctx, wait); err != nil {
+		// hand the reserved token back
+		r.mu.Lock()
+		r.tokens++
+		r.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// observe adapts the rate to the response status
+func (r *rateLimiter) observe(resp *http.Response) {
+	if r == nil || resp == nil || r.maxRate <= 0 {
+		return
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if resp.StatusCode == http.StatusTooManyRequests {
+		r.stats.Throttled++
+		r.rate = math.Max(r.minRate, r.rate/2)
+	} else if resp.StatusCode < http.StatusInternalServerError {
+		r.rate = math.Min(r.maxRate, r.rate+r.maxRate/20)
+	}
+	r.stats.RequestsPerSecond = r.rate
+}
+
+// Stats returns a snapshot of the limiter statistics
+func (r *rateLimiter) Stats() LimiterStats {
+	if r == nil {
+		return LimiterStats{}
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.stats
+}
+
+// releaseOnClose releases the limiter slot once the response body is closed
+type releaseOnClose struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *releaseOnClose) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
@@ -0,0 +1,155 @@
+package confluentcloud
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRateLimit_MaxInFlight(t *testing.T) {
+	var inFlight, peak int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := atomic.AddInt32(&inFlight, 1)
+		for {
+			p := atomic.LoadInt32(&peak)
+			if n <= p || atomic.CompareAndSwapInt32(&peak, p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		atomic.AddInt32(&inFlight, -1)
+		w.Write([]byte(`"ok"`))
+	}))
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRateLimit(&RateLimit{MaxInFlight: 2})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+			_, err := api.Request(req)
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), peak)
+	stats := api.RateLimiterStats()
+	assert.Equal(t, 0, stats.InFlight)
+	assert.Equal(t, 0, stats.Waiting)
+}
+
+func TestRateLimit_TokenBucket(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`"ok"`))
+	}))
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRateLimit(&RateLimit{RequestsPerSecond: 100, Burst: 1})
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		_, err := api.Request(req)
+		assert.Nil(t, err)
+	}
+	assert.True(t, time.Since(start) >= 35*time.Millisecond)
+	assert.True(t, api.RateLimiterStats().Waits > 0)
+}
+
+func TestRateLimit_AdaptsToThrottling(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRateLimit(&RateLimit{RequestsPerSecond: 1000, Burst: 10, MinRequestsPerSecond: 300})
+	api.SetRetryPolicy(nil)
+
+	for i := 0; i < 3; i++ {
+		req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+		_, err := api.Request(req)
+		assert.NotNil(t, err)
+	}
+
+	stats := api.RateLimiterStats()
+	assert.Equal(t, int64(3), stats.Throttled)
+	assert.Equal(t, float64(300), stats.RequestsPerSecond)
+
+	api.limiter.observe(&http.Response{StatusCode: http.StatusOK})
+	assert.Equal(t, float64(350), api.RateLimiterStats().RequestsPerSecond)
+}
+
+func TestRateLimit_WaitHonoursContext(t *testing.T) {
+	r := newRateLimiter(RateLimit{RequestsPerSecond: 1, Burst: 1, MaxInFlight: 1})
+
+	release, err := r.acquire(context.Background())
+	assert.Nil(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, err = r.acquire(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+
+	release()
+	ctx, cancel2 := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel2()
+	_, err = r.acquire(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+	assert.Equal(t, 0, r.Stats().InFlight)
+}
+
+func TestRateLimit_Disabled(t *testing.T) {
+	api, err := newAPI("https://test.test", "username", "token")
+	assert.Nil(t, err)
+	api.SetRateLimit(&RateLimit{RequestsPerSecond: 1})
+	api.SetRateLimit(nil)
+	assert.Nil(t, api.limiter)
+	assert.Equal(t, LimiterStats{}, api.RateLimiterStats())
+}
+
+func TestRateLimit_ReplacedWhileRequesting(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`"ok"`))
+	}))
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 10; j++ {
+				req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+				_, err := api.Request(req)
+				assert.Nil(t, err)
+			}
+		}()
+	}
+	for i := 0; i < 10; i++ {
+		api.SetRateLimit(&RateLimit{MaxInFlight: 2})
+		api.SetRetryPolicy(&RetryPolicy{MaxAttempts: 2})
+		api.RateLimiterStats()
+		api.SetRateLimit(nil)
+		api.SetRetryPolicy(nil)
+	}
+	wg.Wait()
+}
@@ -1,6 +1,7 @@
 package confluentcloud
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -13,75 +14,136 @@ import (
 
 // Request implements the basic Request function
 func (a *api) Request(req *http.Request) ([]byte, error) {
-	req.Header.Add("Accept", "application/json, */*")
-
-	// only auth if we can auth
-	if (a.username != "") || (a.token != "") {
-		a.Auth(req)
-	}
-
-	Debug("====== Request ======")
-	Debug(req)
-	Debug("====== Request Body ======")
-	if DebugFlag {
-		requestDump, err := httputil.DumpRequest(req, true)
-		if err != nil {
-			fmt.Println(err)
-		}
-		fmt.Println(string(requestDump))
-	}
-	Debug("====== /Request Body ======")
-	Debug("====== /Request ======")
-
-	resp, err := a.client.Do(req)
+	resp, err := a.do(req)
 	if err != nil {
 		return nil, err
 	}
-	Debug(fmt.Sprintf("====== Response Status Code: %d ======", resp.StatusCode))
 
 	res, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
-	resp.Body.Close()
 
 	Debug("====== Response Body ======")
 	Debug(string(res))
 	Debug("====== /Response Body ======")
 
 	switch resp.StatusCode {
-	case http.StatusOK, http.StatusCreated, http.StatusPartialContent:
+	case http.StatusOK, http.StatusCreated, http.StatusAccepted, http.StatusPartialContent:
 		return res, nil
 	case http.StatusNoContent, http.StatusResetContent:
 		return nil, nil
-	case http.StatusUnauthorized:
-		return nil, fmt.Errorf("authentication failed")
-	case http.StatusServiceUnavailable:
-		return nil, fmt.Errorf("service is not available: %s", resp.Status)
-	case http.StatusInternalServerError:
-		return nil, fmt.Errorf("internal server error: %s", resp.Status)
-	case http.StatusConflict:
-		return nil, fmt.Errorf("conflict: %s", resp.Status)
 	}
 
-	return nil, fmt.Errorf("unknown response status: %s", resp.Status)
+	return nil, newAPIError(req, resp, res)
+}
+
+// do sends req, retrying it as defined by the retry policy,
+// and returns the final response with its body left open
+func (a *api) do(req *http.Request) (*http.Response, error) {
+	req.Header.Add("Accept", "application/json, */*")
+
+	// only auth if we can auth
+	if (a.username != "") || (a.token != "") {
+		a.Auth(req)
+	}
+
+	// a policy replaced while the request runs applies to the next request
+	retry, limiter := a.policies()
+	for attempt := 1; ; attempt++ {
+		if attempt > 1 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+
+		Debug("====== Request ======")
+		Debug(req)
+		Debug("====== Request Body ======")
+		if DebugFlag {
+			// streamed bodies are not dumped as that would read them
+			requestDump, err := httputil.DumpRequest(req, req.GetBody != nil)
+			if err != nil {
+				fmt.Println(err)
+			}
+			fmt.Println(string(requestDump))
+		}
+		Debug("====== /Request Body ======")
+		Debug("====== /Request ======")
+
+		release, err := limiter.acquire(req.Context())
+		if err != nil {
+			return nil, err
+		}
+		resp, err := a.client.Do(req)
+		if err != nil {
+			release()
+		} else {
+			Debug(fmt.Sprintf("====== Response Status Code: %d ======", resp.StatusCode))
+			limiter.observe(resp)
+			resp.Body = &releaseOnClose{ReadCloser: resp.Body, release: release}
+		}
+		if !retry.shouldRetry(req, resp, err, attempt) {
+			return resp, err
+		}
+
+		delay := retry.delay(resp, attempt)
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		Debug(fmt.Sprintf("====== Retrying in %s ======", delay))
+		if err := sleep(req.Context(), delay); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// RequestWithContext sends req bound to ctx, so the call is aborted
+// as soon as ctx is cancelled or its deadline passes
+func (a *api) RequestWithContext(ctx context.Context, req *http.Request) ([]byte, error) {
+	return a.Request(req.WithContext(ctx))
 }
 
 // SendContentRequest sends content related requests
 // this function is used for getting, updating and deleting content
 func (a *api) SendContentRequest(ep *url.URL, method string, c *Content) (*Content, error) {
-	var body io.Reader
+	return a.SendContentRequestWithContext(context.Background(), ep, method, c)
+}
+
+// SendContentRequestWithContext sends content related requests using the given context
+func (a *api) SendContentRequestWithContext(ctx context.Context, ep *url.URL, method string, c *Content) (*Content, error) {
+	var in interface{}
 	if c != nil {
-		js, err := json.Marshal(c)
+		in = c
+	}
+
+	var content Content
+	err := a.sendRequest(ctx, ep, method, in, &content)
+	if err != nil {
+		return nil, err
+	}
+
+	return &content, nil
+}
+
+// sendRequest sends in, when not nil, as JSON body and unmarshals the response into out, when not nil
+func (a *api) sendRequest(ctx context.Context, ep *url.URL, method string, in interface{}, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		js, err := json.Marshal(in)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		body = strings.NewReader(string(js))
 	}
 
-	req, err := http.NewRequest(method, ep.String(), body)
+	req, err := http.NewRequestWithContext(ctx, method, ep.String(), body)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if body != nil {
@@ -90,15 +152,11 @@ func (a *api) SendContentRequest(ep *url.URL, method string, c *Content) (*Conte
 
 	res, err := a.Request(req)
 	if err != nil {
-		return nil, err
+		return err
 	}
 
-	var content Content
-
-	err = json.Unmarshal(res, &content)
-	if err != nil {
-		return nil, err
+	if out == nil {
+		return nil
 	}
-
-	return &content, nil
+	return json.Unmarshal(res, out)
 }
@@ -1,7 +1,9 @@
 package confluentcloud
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -31,6 +33,8 @@ func TestRequest(t *testing.T) {
 
 	api, err := newAPI(server.URL+"/wiki/rest/api", "userame", "token")
 	assert.Nil(t, err)
+	// the status mapping is checked on the first attempt
+	api.SetRetryPolicy(nil)
 
 	testValues := []testValuesRequest{
 		{"/test", "\"test\"", nil},
@@ -57,6 +61,27 @@ func TestRequest(t *testing.T) {
 	}
 }
 
+func TestRequestWithContext(t *testing.T) {
+	server := confluenceRestAPIStub()
+	defer server.Close()
+
+	api, err := newAPI(server.URL+"/wiki/rest/api", "userame", "token")
+	assert.Nil(t, err)
+
+	req, err := http.NewRequest(http.MethodGet, api.endPoint.String()+"/test", nil)
+	assert.Nil(t, err)
+
+	b, err := api.RequestWithContext(context.Background(), req)
+	assert.Nil(t, err)
+	assert.Equal(t, "\"test\"", string(b))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	b, err = api.RequestWithContext(ctx, req)
+	assert.Nil(t, b)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
+
 func confluenceRestAPIStub() *httptest.Server {
 	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var resp interface{}
@@ -0,0 +1,181 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defines how requests failing with a transient error are retried
+type RetryPolicy struct {
+	MaxAttempts        int           // total number of attempts, including the first one
+	BaseDelay          time.Duration // delay before the first retry, doubled on every attempt
+	MaxDelay           time.Duration // upper bound of the delay, a longer wait requested by the server is not retried
+	Jitter             float64       // fraction (0-1) of the delay that is randomised
+	RetryableStatus    []int         // status codes that trigger a retry
+	RetryNonIdempotent bool          // also retry POST and PATCH requests
+}
+
+// DefaultRetryPolicy returns a policy retrying rate limited and unavailable responses
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    30 * time.Second,
+		Jitter:      0.2,
+		RetryableStatus: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// SetRetryPolicy sets the retry policy used by every request, nil disables retries.
+// New clients use DefaultRetryPolicy.
+func (a *api) SetRetryPolicy(p *RetryPolicy) {
+	a.mu.Lock()
+	a.retry = p
+	a.mu.Unlock()
+}
+
+// policies returns the retry policy and rate limiter a request is sent with
+func (a *api) policies() (*RetryPolicy, *rateLimiter) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.retry, a.limiter
+}
+
+// shouldRetry decides whether the attempt which produced resp or err is retried
+func (p *RetryPolicy) shouldRetry(req *http.Request, resp *http.Response, err error, attempt int) bool {
+	if p == nil || attempt >= p.MaxAttempts || req.Context().Err() != nil {
+		return false
+	}
+	// bodies which can not be rewound are only sent once
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if !p.RetryNonIdempotent && !isIdempotent(req.Method) {
+		return false
+	}
+	if err != nil {
+		return true
+	}
+	// waiting longer than the caller allows is left to the caller, the response is returned
+	if d, ok := retryAfter(resp.Header, time.Now()); ok && p.MaxDelay != 0 && d > p.MaxDelay {
+		return false
+	}
+	for _, s := range p.RetryableStatus {
+		if resp.StatusCode == s {
+			return true
+		}
+	}
+	return false
+}
+
+// delay returns how long to wait before the next attempt.
+// Delays requested by the server take precedence over the backoff.
+func (p *RetryPolicy) delay(resp *http.Response, attempt int) time.Duration {
+	if resp != nil {
+		if d, ok := retryAfter(resp.Header, time.Now()); ok {
+			return d
+		}
+	}
+
+	d := p.BaseDelay
+	for i := 1; i < attempt && (p.MaxDelay == 0 || d < p.MaxDelay); i++ {
+		d *= 2
+	}
+	if p.MaxDelay != 0 && d > p.MaxDelay {
+		d = p.MaxDelay
+	}
+	if p.Jitter > 0 {
+		d = time.Duration(float64(d) * (1 + p.Jitter*(2*rand.Float64()-1)))
+	}
+	return d
+}
+
+// retryAfter reads the delay requested through the Retry-After or X-RateLimit-Reset headers
+func retryAfter(h http.Header, now time.Time) (time.Duration, bool) {
+	if v := h.Get("Retry-After"); v != "" {
+		if s, err := strconv.Atoi(v); err == nil && s >= 0 {
+			return time.Duration(s) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	if v := h.Get("X-RateLimit-Reset"); v != "" {
+		if t, err := time.Parse(time.RFC3339, v); err == nil {
+			return nonNegative(t.Sub(now)), true
+		}
+	}
+	return 0, false
+}
+
+func nonNegative(d time.Duration) time.Duration {
+	if d < 0 {
+		return 0
+	}
+	return d
+}
+
+// isIdempotent reports whether requests using method can safely be sent twice
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// sleep waits for d or until ctx is done
+func sleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// defaultPollInterval is used by the pollers when no positive interval is given
+const defaultPollInterval = time.Second
+
+// poll calls fn every interval until it reports done, returns an error or ctx ends.
+// Intervals which are not positive are replaced by defaultPollInterval.
+func poll(ctx context.Context, interval time.Duration, fn func() (bool, error)) error {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	for {
+		done, err := fn()
+		if done || err != nil {
+			return err
+		}
+		if err := sleep(ctx, interval); err != nil {
+			return err
+		}
+	}
+}
+
+// retryOnConflict runs fn until it returns an error other than ErrConflict
+// or fn was retried the given number of times
+func retryOnConflict(ctx context.Context, retries int, fn func() error) error {
+	for attempt := 0; ; attempt++ {
+		err := fn()
+		if err == nil || !errors.Is(err, ErrConflict) || attempt >= retries {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		Debug("====== Conflict, retrying ======")
+	}
+}
@@ -0,0 +1,232 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.BaseDelay = time.Millisecond
+	p.MaxDelay = 5 * time.Millisecond
+	return p
+}
+
+// flakyServer fails the first `failures` requests with status and records the received bodies
+func flakyServer(failures int32, status int, bodies *[]string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		if bodies != nil {
+			*bodies = append(*bodies, string(b))
+		}
+		if atomic.AddInt32(&calls, 1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(status)
+			return
+		}
+		w.Write([]byte(`"ok"`))
+	}))
+	return server, &calls
+}
+
+func TestRequest_RetriesTransientErrors(t *testing.T) {
+	server, calls := flakyServer(2, http.StatusTooManyRequests, nil)
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRetryPolicy(testRetryPolicy())
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	b, err := api.Request(req)
+	assert.Nil(t, err)
+	assert.Equal(t, `"ok"`, string(b))
+	assert.Equal(t, int32(3), *calls)
+}
+
+func TestRequest_StopsAfterMaxAttempts(t *testing.T) {
+	server, calls := flakyServer(10, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRetryPolicy(testRetryPolicy())
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err = api.Request(req)
+	assert.Equal(t, "service is not available: 503 Service Unavailable", err.Error())
+	assert.Equal(t, int32(4), *calls)
+}
+
+func TestRequest_DefaultRetryPolicy(t *testing.T) {
+	a, err := newAPI("https://example.atlassian.net/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultRetryPolicy(), a.retry)
+
+	withClient, err := NewAPIWithClient("https://example.atlassian.net/wiki/rest/api", http.DefaultClient)
+	assert.Nil(t, err)
+	assert.Equal(t, DefaultRetryPolicy(), withClient.(*api).retry)
+}
+
+func TestRequest_NoRetryBeyondMaxDelay(t *testing.T) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		w.Header().Set("Retry-After", "3600")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err = api.Request(req)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(1), calls)
+}
+
+func TestRequest_NoRetryWhenDisabled(t *testing.T) {
+	server, calls := flakyServer(1, http.StatusTooManyRequests, nil)
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRetryPolicy(nil)
+
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err = api.Request(req)
+	assert.True(t, errors.Is(err, ErrRateLimited))
+	assert.Equal(t, int32(1), *calls)
+}
+
+func TestRequest_RetryNonIdempotent(t *testing.T) {
+	var bodies []string
+	server, calls := flakyServer(1, http.StatusServiceUnavailable, &bodies)
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRetryPolicy(testRetryPolicy())
+
+	req, _ := http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	_, err = api.Request(req)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), *calls)
+
+	p := testRetryPolicy()
+	p.RetryNonIdempotent = true
+	api.SetRetryPolicy(p)
+	atomic.StoreInt32(calls, 0)
+	bodies = nil
+
+	req, _ = http.NewRequest(http.MethodPost, server.URL, strings.NewReader("payload"))
+	b, err := api.Request(req)
+	assert.Nil(t, err)
+	assert.Equal(t, `"ok"`, string(b))
+	assert.Equal(t, []string{"payload", "payload"}, bodies)
+}
+
+func TestRequest_NoRetryForUnrewindableBody(t *testing.T) {
+	server, calls := flakyServer(1, http.StatusServiceUnavailable, nil)
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	api.SetRetryPolicy(testRetryPolicy())
+
+	req, _ := http.NewRequest(http.MethodPut, server.URL, ioutil.NopCloser(strings.NewReader("payload")))
+	_, err = api.Request(req)
+	assert.NotNil(t, err)
+	assert.Equal(t, int32(1), *calls)
+}
+
+func TestRequest_RetryWaitHonoursContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	api, err := newAPI(server.URL, "username", "token")
+	assert.Nil(t, err)
+	p := testRetryPolicy()
+	p.MaxDelay = time.Minute
+	api.SetRetryPolicy(p)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	req, _ := http.NewRequest(http.MethodGet, server.URL, nil)
+	_, err = api.RequestWithContext(ctx, req)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2021, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	h := http.Header{}
+	_, ok := retryAfter(h, now)
+	assert.False(t, ok)
+
+	h.Set("Retry-After", "7")
+	d, ok := retryAfter(h, now)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, d)
+
+	h.Set("Retry-After", now.Add(3*time.Second).Format(http.TimeFormat))
+	d, _ = retryAfter(h, now)
+	assert.Equal(t, 3*time.Second, d)
+
+	h = http.Header{}
+	h.Set("X-RateLimit-Reset", now.Add(2*time.Second).Format(time.RFC3339))
+	d, _ = retryAfter(h, now)
+	assert.Equal(t, 2*time.Second, d)
+}
+
+func TestRetryPolicy_Delay(t *testing.T) {
+	p := &RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}
+	assert.Equal(t, time.Second, p.delay(nil, 1))
+	assert.Equal(t, 2*time.Second, p.delay(nil, 2))
+	assert.Equal(t, 4*time.Second, p.delay(nil, 3))
+	assert.Equal(t, 5*time.Second, p.delay(nil, 4))
+
+	p.Jitter = 0.5
+	for i := 0; i < 20; i++ {
+		d := p.delay(nil, 1)
+		assert.True(t, d >= 500*time.Millisecond && d <= 1500*time.Millisecond)
+	}
+}
+
+func TestPoll(t *testing.T) {
+	calls := 0
+	err := poll(context.Background(), time.Millisecond, func() (bool, error) {
+		calls++
+		return calls == 3, nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, 3, calls)
+
+	// a non positive interval does not spin
+	calls = 0
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err = poll(ctx, 0, func() (bool, error) {
+		calls++
+		return false, nil
+	})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Equal(t, 1, calls)
+
+	boom := errors.New("boom")
+	assert.Equal(t, boom, poll(context.Background(), -time.Second, func() (bool, error) { return false, boom }))
+}
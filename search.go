@@ -1,10 +1,12 @@
 package confluentcloud
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
 )
 
 // getSearchEndpoint creates the correct api endpoint
@@ -14,13 +16,18 @@ func (a *api) getSearchEndpoint() (*url.URL, error) {
 
 // GetSearchContentResults queries content using ContentQuery
 func (a *api) GetSearchContentResults(query SearchContentQuery) (*SearchPageResults, error) {
+	return a.GetSearchContentResultsWithContext(context.Background(), query)
+}
+
+// GetSearchContentResultsWithContext queries content using ContentQuery and the given context
+func (a *api) GetSearchContentResultsWithContext(ctx context.Context, query SearchContentQuery) (*SearchPageResults, error) {
 	ep, err := a.getSearchEndpoint()
 	if err != nil {
 		return nil, err
 	}
 	ep.RawQuery = addSearchQueryParams(query).Encode()
 
-	req, err := http.NewRequest(http.MethodGet, ep.String(), nil)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ep.String(), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -54,11 +61,57 @@ func addSearchQueryParams(query SearchContentQuery) *url.Values {
 	if query.Limit != 0 {
 		data.Set("limit", strconv.Itoa(query.Limit))
 	}
-	if query.cursor != "" {
-		data.Set("cursor", query.cursor)
+	if query.Cursor != "" {
+		data.Set("cursor", query.Cursor)
 	}
 	if query.IncludeArchivedSpaces {
-		data.Set("includeArchivedSpaces ", "true")
+		data.Set("includeArchivedSpaces", "true")
 	}
 	return &data
 }
+
+// StreamSearchResults calls fn for every result of query, following the result cursors.
+// When max is greater than zero at most max results are streamed.
+// The iteration stops at the first error returned by fn.
+func (a *api) StreamSearchResults(ctx context.Context, query SearchContentQuery, max int, fn func(SearchPageResult) error) error {
+	p := a.PaginateSearch(ctx, query)
+	n := 0
+	for p.Next() {
+		for _, r := range p.Page().Results {
+			if max > 0 && n >= max {
+				return nil
+			}
+			if err := fn(r); err != nil {
+				return err
+			}
+			n++
+		}
+	}
+	return p.Err()
+}
+
+// NextCursor returns the cursor of the next page, empty on the last page
+func (s *SearchPageResults) NextCursor() string {
+	return cursorFromLink(s.Links.Next)
+}
+
+// PrevCursor returns the cursor of the previous page, empty on the first page
+func (s *SearchPageResults) PrevCursor() string {
+	return cursorFromLink(s.Links.Prev)
+}
+
+// cursorFromLink extracts the cursor query parameter from a relative or absolute link
+func cursorFromLink(link string) string {
+	if link == "" {
+		return ""
+	}
+	i := strings.Index(link, "?")
+	if i < 0 {
+		return ""
+	}
+	values, err := url.ParseQuery(link[i+1:])
+	if err != nil {
+		return ""
+	}
+	return values.Get("cursor")
+}
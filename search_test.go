@@ -1,10 +1,13 @@
 package confluentcloud
 
 import (
-	"github.com/stretchr/testify/assert"
+	"context"
+	"errors"
 	"net/url"
 	"reflect"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func Test_api_GetSearchContentResults(t *testing.T) {
@@ -49,3 +52,70 @@ func Test_addSearchQueryParams(t *testing.T) {
 		t.Errorf("addSearchQueryParams() = %v, want %v", got, &want)
 	}
 }
+
+func Test_addSearchQueryParams_Cursor(t *testing.T) {
+	query := SearchContentQuery{
+		Cql:                   "type=page",
+		Cursor:                "abc",
+		IncludeArchivedSpaces: true,
+	}
+	got := addSearchQueryParams(query)
+	assert.Equal(t, "abc", got.Get("cursor"))
+	assert.Equal(t, "true", got.Get("includeArchivedSpaces"))
+}
+
+func Test_api_GetSearchContentResults_Cursor(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	first, err := api.GetSearchContentResults(SearchContentQuery{Cql: "type=page"})
+	assert.Nil(t, err)
+	assert.Equal(t, "abc", first.NextCursor())
+	assert.Equal(t, "", first.PrevCursor())
+
+	second, err := api.GetSearchContentResults(SearchContentQuery{Cql: "type=page", Cursor: first.NextCursor()})
+	assert.Nil(t, err)
+	assert.Equal(t, "c", second.Results[0].Title)
+	assert.Equal(t, "", second.NextCursor())
+}
+
+func Test_cursorFromLink(t *testing.T) {
+	assert.Equal(t, "", cursorFromLink(""))
+	assert.Equal(t, "", cursorFromLink("/rest/api/search"))
+	assert.Equal(t, "a+b", cursorFromLink("/rest/api/search?next=true&cursor=a%2Bb&limit=25"))
+	assert.Equal(t, "xyz", cursorFromLink("https://test.atlassian.net/wiki/rest/api/search?prev=true&cursor=xyz"))
+}
+
+func Test_api_StreamSearchResults(t *testing.T) {
+	server := pagedServer()
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	var titles, ids []string
+	collect := func(r SearchPageResult) error {
+		titles = append(titles, r.Title)
+		ids = append(ids, r.Content.ID)
+		return nil
+	}
+
+	err = api.StreamSearchResults(context.Background(), SearchContentQuery{Cql: "type=page"}, 0, collect)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, titles)
+	assert.Equal(t, []string{"a", "b", "c", "d"}, ids)
+
+	titles = nil
+	err = api.StreamSearchResults(context.Background(), SearchContentQuery{Cql: "type=page"}, 3, collect)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, titles)
+
+	stop := errors.New("stop")
+	err = api.StreamSearchResults(context.Background(), SearchContentQuery{Cql: "type=page"}, 0, func(SearchPageResult) error {
+		return stop
+	})
+	assert.Equal(t, stop, err)
+}
@@ -0,0 +1,264 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// getSpaceEndpoint creates the correct api endpoint
+func (a *api) getSpaceEndpoint() (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/space")
+}
+
+// getSpaceKeyEndpoint creates the correct api endpoint by given space key
+func (a *api) getSpaceKeyEndpoint(key string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/space/" + key)
+}
+
+// getSpaceContentEndpoint creates the correct api endpoint by given space key and content type
+func (a *api) getSpaceContentEndpoint(key string, t string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/space/" + key + "/content/" + t)
+}
+
+// SpacePaginator iterates over the pages of a space collection
+type SpacePaginator struct {
+	p    paginator
+	page *SpacePage
+}
+
+// PaginateSpaces returns a paginator over the spaces matching query
+func (a *api) PaginateSpaces(ctx context.Context, query SpaceQuery) *SpacePaginator {
+	s := &SpacePaginator{}
+	ep, err := a.getSpaceEndpoint()
+	if err != nil {
+		s.p.err = err
+		return s
+	}
+	ep.RawQuery = addSpaceQueryParams(query).Encode()
+	s.p = newPaginator(ctx, a, ep.String(), a.baseURL(), s.decode)
+	return s
+}
+
+func (s *SpacePaginator) decode(b []byte) (Links, error) {
+	var page SpacePage
+	if err := json.Unmarshal(b, &page); err != nil {
+		return Links{}, err
+	}
+	s.page = &page
+	return page.Links, nil
+}
+
+// Next fetches the next page, it returns false once all pages were read or an error occurred
+func (s *SpacePaginator) Next() bool {
+	return s.p.fetch()
+}
+
+// Page returns the current page
+func (s *SpacePaginator) Page() *SpacePage {
+	return s.page
+}
+
+// Err returns the error which stopped the iteration
+func (s *SpacePaginator) Err() error {
+	return s.p.err
+}
+
+// All reads the remaining pages and returns their spaces.
+// When max is greater than zero at most max spaces are returned.
+func (s *SpacePaginator) All(max int) ([]Space, error) {
+	var spaces []Space
+	if err := s.p.all(max, &spaces, func() interface{} { return s.page.Results }); err != nil {
+		return nil, err
+	}
+	return spaces, nil
+}
+
+// GetSpace gets a space by key
+func (a *api) GetSpace(ctx context.Context, key string, expand []string) (*Space, error) {
+	ep, err := a.getSpaceKeyEndpoint(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(expand) != 0 {
+		ep.RawQuery = url.Values{"expand": {strings.Join(expand, ",")}}.Encode()
+	}
+
+	var space Space
+	err = a.sendRequest(ctx, ep, http.MethodGet, nil, &space)
+	if err != nil {
+		return nil, err
+	}
+	return &space, nil
+}
+
+// CreateSpace creates a global space, or a private global space visible only to its creator.
+// Personal spaces cannot be created through the API.
+func (a *api) CreateSpace(ctx context.Context, s CreateSpaceRequest) (*Space, error) {
+	if s.Key == "" {
+		return nil, errors.New("space key empty")
+	}
+	if s.Name == "" {
+		return nil, errors.New("name empty")
+	}
+
+	ep, err := a.getSpaceEndpoint()
+	if err != nil {
+		return nil, err
+	}
+	if s.Private {
+		ep.Path += "/_private"
+	}
+
+	payload := spaceRequestPayload{Key: s.Key, Name: s.Name}
+	if s.Description != "" {
+		payload.Description = plainDescription(s.Description)
+	}
+
+	var space Space
+	err = a.sendRequest(ctx, ep, http.MethodPost, payload, &space)
+	if err != nil {
+		return nil, err
+	}
+	return &space, nil
+}
+
+// UpdateSpace updates the name, description or homepage of a space
+func (a *api) UpdateSpace(ctx context.Context, key string, s UpdateSpaceRequest) (*Space, error) {
+	payload := spaceRequestPayload{Name: s.Name}
+	if s.Description != "" {
+		payload.Description = plainDescription(s.Description)
+	}
+	if s.HomepageID != "" {
+		payload.Homepage = &idPayload{ID: s.HomepageID}
+	}
+	return a.updateSpace(ctx, key, payload)
+}
+
+// ArchiveSpace archives a space
+func (a *api) ArchiveSpace(ctx context.Context, key string) (*Space, error) {
+	return a.updateSpace(ctx, key, spaceRequestPayload{Status: "archived"})
+}
+
+// RestoreSpace restores an archived space
+func (a *api) RestoreSpace(ctx context.Context, key string) (*Space, error) {
+	return a.updateSpace(ctx, key, spaceRequestPayload{Status: "current"})
+}
+
+func (a *api) updateSpace(ctx context.Context, key string, payload spaceRequestPayload) (*Space, error) {
+	ep, err := a.getSpaceKeyEndpoint(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var space Space
+	err = a.sendRequest(ctx, ep, http.MethodPut, payload, &space)
+	if err != nil {
+		return nil, err
+	}
+	return &space, nil
+}
+
+// DeleteSpace deletes a space with all its content.
+// The deletion runs as a long running task, which is returned to be waited for with WaitForTask.
+func (a *api) DeleteSpace(ctx context.Context, key string) (*LongTaskRef, error) {
+	ep, err := a.getSpaceKeyEndpoint(key)
+	if err != nil {
+		return nil, err
+	}
+
+	var task LongTaskRef
+	err = a.sendRequest(ctx, ep, http.MethodDelete, nil, &task)
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// PaginateSpaceContent returns a paginator over the content of the given type (page, blogpost) in a space
+func (a *api) PaginateSpaceContent(ctx context.Context, key string, contentType string, query SpaceContentQuery) *ContentPaginator {
+	ep, err := a.getSpaceContentEndpoint(key, contentType)
+	if err != nil {
+		c := &ContentPaginator{}
+		c.p.err = err
+		return c
+	}
+	ep.RawQuery = addSpaceContentQueryParams(query).Encode()
+	return a.newContentPaginator(ctx, ep.String())
+}
+
+// GetSpaceRoots gets the homepage and the root pages of a space.
+// expand applies to the root pages.
+func (a *api) GetSpaceRoots(ctx context.Context, key string, expand []string) (*SpaceRoots, error) {
+	space, err := a.GetSpace(ctx, key, []string{"homepage"})
+	if err != nil {
+		return nil, err
+	}
+
+	pages, err := a.PaginateSpaceContent(ctx, key, "page", SpaceContentQuery{Depth: DepthRoot, Expand: expand}).All(0)
+	if err != nil {
+		return nil, err
+	}
+
+	roots := &SpaceRoots{Pages: pages}
+	if space.Homepage != nil && space.Homepage.ID != "" {
+		roots.Homepage = space.Homepage
+	}
+	return roots, nil
+}
+
+func plainDescription(value string) *spaceDescriptionPayload {
+	return &spaceDescriptionPayload{Plain: ContentBody{Value: value, Representation: "plain"}}
+}
+
+// addSpaceQueryParams adds the defined query parameters
+func addSpaceQueryParams(query SpaceQuery) *url.Values {
+	data := url.Values{}
+	for _, key := range query.SpaceKey {
+		data.Add("spaceKey", key)
+	}
+	if query.Type != "" {
+		data.Set("type", query.Type)
+	}
+	if query.Status != "" {
+		data.Set("status", query.Status)
+	}
+	for _, label := range query.Label {
+		data.Add("label", label)
+	}
+	if query.Favourite {
+		data.Set("favourite", "true")
+	}
+	if len(query.Expand) != 0 {
+		data.Set("expand", strings.Join(query.Expand, ","))
+	}
+	if query.Start != 0 {
+		data.Set("start", strconv.Itoa(query.Start))
+	}
+	if query.Limit != 0 {
+		data.Set("limit", strconv.Itoa(query.Limit))
+	}
+	return &data
+}
+
+// addSpaceContentQueryParams adds the defined query parameters
+func addSpaceContentQueryParams(query SpaceContentQuery) *url.Values {
+	data := url.Values{}
+	if query.Depth != "" {
+		data.Set("depth", query.Depth)
+	}
+	if len(query.Expand) != 0 {
+		data.Set("expand", strings.Join(query.Expand, ","))
+	}
+	if query.Start != 0 {
+		data.Set("start", strconv.Itoa(query.Start))
+	}
+	if query.Limit != 0 {
+		data.Set("limit", strconv.Itoa(query.Limit))
+	}
+	return &data
+}
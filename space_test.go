@@ -0,0 +1,222 @@
+package confluentcloud
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func spaceServer(t *testing.T) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/wiki/rest/api/space" && r.Method == http.MethodGet:
+			q := r.URL.Query()
+			assert.Equal(t, "global", q.Get("type"))
+			assert.Equal(t, []string{"docs", "team"}, q["label"])
+			assert.Equal(t, "true", q.Get("favourite"))
+			if q.Get("start") == "" {
+				w.Write([]byte(`{"results":[{"id":1,"key":"DOC","name":"Docs"},{"id":2,"key":"ENG","name":"Engineering"}],` +
+					`"_links":{"base":"` + server.URL + `/wiki","next":"/rest/api/space?type=global&label=docs&label=team&favourite=true&start=2"}}`))
+				return
+			}
+			w.Write([]byte(`{"results":[{"id":3,"key":"HR","name":"People"}]}`))
+		case r.URL.Path == "/wiki/rest/api/space/DOC" && r.Method == http.MethodGet:
+			assert.Equal(t, "homepage,description.plain,icon", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"id":1,"key":"DOC","name":"Docs","type":"global","status":"current",` +
+				`"icon":{"path":"/images/logo/default-space-logo.svg","width":48,"height":48,"isDefault":true},` +
+				`"description":{"plain":{"value":"All docs","representation":"plain"}},` +
+				`"homepage":{"id":"65537","type":"page","title":"Docs Home"},` +
+				`"metadata":{"labels":{"results":[{"prefix":"team","name":"docs"}]}}}`))
+		case (r.URL.Path == "/wiki/rest/api/space" || r.URL.Path == "/wiki/rest/api/space/_private") && r.Method == http.MethodPost:
+			var p spaceRequestPayload
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+			description := ""
+			if p.Description != nil {
+				description = p.Description.Plain.Value
+			}
+			w.Write([]byte(`{"id":4,"key":"` + p.Key + `","name":"` + p.Name + `","type":"global","description":{"plain":{"value":"` + description + `","representation":"plain"}},` +
+				`"_links":{"self":"` + r.URL.Path + `"}}`))
+		case r.URL.Path == "/wiki/rest/api/space/DOC" && r.Method == http.MethodPut:
+			var p map[string]interface{}
+			assert.Nil(t, json.NewDecoder(r.Body).Decode(&p))
+			b, _ := json.Marshal(p)
+			w.Write(b)
+		case r.URL.Path == "/wiki/rest/api/space/DOC" && r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusAccepted)
+			w.Write([]byte(`{"id":"task-1","links":{"status":"/rest/api/longtask/task-1"}}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func Test_PaginateSpaces(t *testing.T) {
+	server := spaceServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	query := SpaceQuery{Type: "global", Label: []string{"docs", "team"}, Favourite: true}
+	spaces, err := api.PaginateSpaces(context.Background(), query).All(0)
+	assert.Nil(t, err)
+	assert.Len(t, spaces, 3)
+	assert.Equal(t, "HR", spaces[2].Key)
+
+	spaces, err = api.PaginateSpaces(context.Background(), query).All(1)
+	assert.Nil(t, err)
+	assert.Equal(t, []Space{{ID: 1, Key: "DOC", Name: "Docs"}}, spaces)
+}
+
+func Test_GetSpace(t *testing.T) {
+	server := spaceServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	s, err := api.GetSpace(context.Background(), "DOC", []string{"homepage", "description.plain", "icon"})
+	assert.Nil(t, err)
+	assert.Equal(t, "All docs", s.Description.Plain.Value)
+	assert.True(t, s.Icon.IsDefault)
+	assert.Equal(t, "65537", s.Homepage.ID)
+	assert.Equal(t, "docs", s.Metadata.Labels.Results[0].Name)
+
+	_, err = api.GetSpace(context.Background(), "NOPE", nil)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func Test_CreateSpace(t *testing.T) {
+	server := spaceServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	s, err := api.CreateSpace(ctx, CreateSpaceRequest{Key: "NEW", Name: "New", Description: "Fresh"})
+	assert.Nil(t, err)
+	assert.Equal(t, "global", s.Type)
+	assert.Equal(t, "Fresh", s.Description.Plain.Value)
+
+	s, err = api.CreateSpace(ctx, CreateSpaceRequest{Key: "ME", Name: "Mine", Private: true})
+	assert.Nil(t, err)
+	assert.Equal(t, "/wiki/rest/api/space/_private", s.Links.Self)
+	assert.Equal(t, "global", s.Type)
+
+	_, err = api.CreateSpace(ctx, CreateSpaceRequest{Name: "New"})
+	assert.Equal(t, "space key empty", err.Error())
+	_, err = api.CreateSpace(ctx, CreateSpaceRequest{Key: "NEW"})
+	assert.Equal(t, "name empty", err.Error())
+}
+
+func Test_UpdateArchiveDeleteSpace(t *testing.T) {
+	server := spaceServer(t)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	s, err := api.UpdateSpace(ctx, "DOC", UpdateSpaceRequest{Name: "Documentation", Description: "Everything", HomepageID: "7"})
+	assert.Nil(t, err)
+	assert.Equal(t, "Documentation", s.Name)
+	assert.Equal(t, "Everything", s.Description.Plain.Value)
+	assert.Equal(t, "7", s.Homepage.ID)
+
+	s, err = api.ArchiveSpace(ctx, "DOC")
+	assert.Nil(t, err)
+	assert.Equal(t, "archived", s.Status)
+
+	s, err = api.RestoreSpace(ctx, "DOC")
+	assert.Nil(t, err)
+	assert.Equal(t, "current", s.Status)
+
+	task, err := api.DeleteSpace(ctx, "DOC")
+	assert.Nil(t, err)
+	assert.Equal(t, &LongTaskRef{ID: "task-1", Links: Links{Status: "/rest/api/longtask/task-1"}}, task)
+}
+
+func TestAddSpaceQueryParams(t *testing.T) {
+	p := addSpaceQueryParams(SpaceQuery{
+		SpaceKey: []string{"A", "B"},
+		Status:   "archived",
+		Expand:   []string{"homepage", "icon"},
+		Start:    5,
+		Limit:    10,
+	})
+
+	assert.Equal(t, []string{"A", "B"}, (*p)["spaceKey"])
+	assert.Equal(t, "archived", p.Get("status"))
+	assert.Equal(t, "homepage,icon", p.Get("expand"))
+	assert.Equal(t, "5", p.Get("start"))
+	assert.Equal(t, "10", p.Get("limit"))
+	assert.Equal(t, "", p.Get("favourite"))
+}
+
+func Test_PaginateSpaceContent(t *testing.T) {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/wiki/rest/api/space/DOC":
+			assert.Equal(t, "homepage", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"id":1,"key":"DOC","homepage":{"id":"10","type":"page","title":"Home"}}`))
+		case "/wiki/rest/api/space/EMPTY":
+			w.Write([]byte(`{"id":2,"key":"EMPTY"}`))
+		case "/wiki/rest/api/space/DOC/content/page", "/wiki/rest/api/space/EMPTY/content/page":
+			assert.Equal(t, DepthRoot, r.URL.Query().Get("depth"))
+			if r.URL.Query().Get("start") == "" {
+				w.Write([]byte(`{"results":[{"id":"10","type":"page","title":"Home"}],` +
+					`"_links":{"base":"` + server.URL + `/wiki","next":"` + r.URL.Path[len("/wiki"):] + `?depth=root&start=1"}}`))
+				return
+			}
+			w.Write([]byte(`{"results":[{"id":"11","type":"page","title":"Orphan"}]}`))
+		case "/wiki/rest/api/space/DOC/content/blogpost":
+			assert.Equal(t, DepthAll, r.URL.Query().Get("depth"))
+			assert.Equal(t, "version", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"results":[{"id":"20","type":"blogpost","title":"News"}]}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	posts, err := api.PaginateSpaceContent(ctx, "DOC", "blogpost", SpaceContentQuery{Depth: DepthAll, Expand: []string{"version"}}).All(0)
+	assert.Nil(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, "News", posts[0].Title)
+
+	roots, err := api.GetSpaceRoots(ctx, "DOC", nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "10", roots.Homepage.ID)
+	assert.Len(t, roots.Pages, 2)
+	assert.Equal(t, "Orphan", roots.Pages[1].Title)
+
+	roots, err = api.GetSpaceRoots(ctx, "EMPTY", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, roots.Homepage)
+	assert.Len(t, roots.Pages, 2)
+
+	_, err = api.GetSpaceRoots(ctx, "NOPE", nil)
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func TestAddSpaceContentQueryParams(t *testing.T) {
+	p := addSpaceContentQueryParams(SpaceContentQuery{Depth: DepthRoot, Expand: []string{"ancestors", "version"}, Start: 25, Limit: 25})
+
+	assert.Equal(t, "root", p.Get("depth"))
+	assert.Equal(t, "ancestors,version", p.Get("expand"))
+	assert.Equal(t, "25", p.Get("start"))
+	assert.Equal(t, "25", p.Get("limit"))
+}
@@ -1,19 +1,97 @@
 package confluentcloud
 
 import (
+	"context"
+	"encoding/json"
+	"io"
 	"net/http"
 	"net/url"
+	"sync"
 	"time"
 )
 
+// API is the Confluence Cloud client.
+// The methods that predate context support come in pairs, X and XWithContext, X using context.Background().
+// Every method added since takes the context as its first argument and has no variant without one,
+// callers without a context pass context.Background().
 type API interface {
 	Request(*http.Request) ([]byte, error)
+	RequestWithContext(context.Context, *http.Request) ([]byte, error)
 	SendContentRequest(*url.URL, string, *Content) (*Content, error)
+	SendContentRequestWithContext(context.Context, *url.URL, string, *Content) (*Content, error)
 	VerifyTLS(bool)
+	SetRetryPolicy(*RetryPolicy)
+	SetRateLimit(*RateLimit)
+	RateLimiterStats() LimiterStats
 	GetContent(ContentQuery) (*Content, error)
+	GetContentWithContext(context.Context, ContentQuery) (*Content, error)
+	GetContentByID(context.Context, string, ContentByIDQuery) (*Results, error)
+	CreateContent(context.Context, CreateContentRequest) (*Results, error)
+	UpdateContent(context.Context, Results, UpdateContentOptions) (*Results, error)
+	UpdateContentFunc(context.Context, string, func(*Results) error, UpdateContentFuncOptions) (*Results, error)
+	TrashContent(context.Context, string) error
+	PurgeContent(context.Context, string) error
+	RestoreContent(context.Context, string) (*Results, error)
+	DeleteContentVersion(context.Context, string, int) error
 	GetContentFromNext(Links) (*Content, error)
+	GetContentFromNextWithContext(context.Context, Links) (*Content, error)
 	GetAttachmentsFromResult(Results, string) ([]Results, error)
+	GetAttachmentsFromResultWithContext(context.Context, Results, string) ([]Results, error)
+	ConvertBody(context.Context, string, ContentBody, ConvertBodyQuery) (*Storage, error)
+	ConvertBodyAsync(context.Context, string, ContentBody, ConvertBodyQuery) (string, error)
+	GetAsyncConvertedBody(context.Context, string) (*AsyncConvertedBody, error)
+	WaitForConvertedBody(context.Context, string, time.Duration) (*AsyncConvertedBody, error)
+	UploadAttachment(context.Context, string, string, io.Reader, UploadAttachmentOptions) (*Results, error)
+	DownloadAttachment(context.Context, Results, DownloadAttachmentOptions) (io.ReadCloser, error)
+	DownloadAttachmentTo(context.Context, Results, io.Writer, DownloadAttachmentOptions) (int64, error)
+	ListAttachments(context.Context, string, AttachmentQuery) ([]FileAttachment, error)
+	UpdateAttachment(context.Context, string, FileAttachment) (*FileAttachment, error)
+	MoveAttachment(context.Context, string, FileAttachment, string) (*FileAttachment, error)
+	GetAttachmentVersions(context.Context, string) ([]Version, error)
+	DownloadAttachmentVersion(context.Context, string, int, DownloadAttachmentOptions) (io.ReadCloser, error)
+	GetLabels(context.Context, string, string) ([]Label, error)
+	AddLabels(context.Context, string, ...Label) ([]Label, error)
+	RemoveLabel(context.Context, string, string) error
+	SearchByLabel(context.Context, string) *SearchPaginator
+	GetContentProperties(context.Context, string) ([]ContentProperty, error)
+	GetContentProperty(context.Context, string, string) (*ContentProperty, error)
+	CreateContentProperty(context.Context, string, string, interface{}) (*ContentProperty, error)
+	UpdateContentProperty(context.Context, string, ContentProperty, UpdatePropertyOptions) (*ContentProperty, error)
+	DeleteContentProperty(context.Context, string, string) error
+	GetContentPropertyValue(context.Context, string, string, interface{}) (int, error)
+	SetContentPropertyValue(context.Context, string, string, interface{}, UpdatePropertyFuncOptions) (*ContentProperty, error)
+	UpdateContentPropertyFunc(context.Context, string, string, interface{}, func() (interface{}, error), UpdatePropertyFuncOptions) (*ContentProperty, error)
+	PaginateSpaces(context.Context, SpaceQuery) *SpacePaginator
+	GetSpace(context.Context, string, []string) (*Space, error)
+	CreateSpace(context.Context, CreateSpaceRequest) (*Space, error)
+	UpdateSpace(context.Context, string, UpdateSpaceRequest) (*Space, error)
+	ArchiveSpace(context.Context, string) (*Space, error)
+	RestoreSpace(context.Context, string) (*Space, error)
+	DeleteSpace(context.Context, string) (*LongTaskRef, error)
+	PaginateSpaceContent(context.Context, string, string, SpaceContentQuery) *ContentPaginator
+	GetSpaceRoots(context.Context, string, []string) (*SpaceRoots, error)
+	PaginateChildPages(context.Context, string, []string) *ContentPaginator
+	PaginateDescendantPages(context.Context, string, []string) *ContentPaginator
+	GetAncestors(context.Context, string) ([]Results, error)
+	WalkTree(context.Context, string, WalkFunc, WalkOptions) (*PageNode, error)
+	MovePage(context.Context, string, MovePosition, string, MovePageOptions) error
+	MoveSubtreeToSpace(context.Context, string, string, MoveSubtreeOptions) (*MoveCheck, error)
+	CopyPage(context.Context, string, CopyPageOptions) (*Results, error)
+	CopyPageHierarchy(context.Context, string, CopyHierarchyOptions) (*LongTaskRef, error)
+	GetTask(context.Context, string) (*LongTask, error)
+	WaitForTask(context.Context, string, time.Duration, func(*LongTask)) (*LongTask, error)
+	GetComments(context.Context, string, CommentQuery) ([]*CommentNode, error)
+	CreateComment(context.Context, string, CreateCommentRequest) (*Results, error)
+	UpdateComment(context.Context, string, ContentBody) (*Results, error)
+	DeleteComment(context.Context, string) error
+	SetCommentResolution(context.Context, string, string) (*Results, error)
+	SetInlineSelection(context.Context, string, InlineProperties) (*Results, error)
 	GetSearchContentResults(SearchContentQuery) (*SearchPageResults, error)
+	GetSearchContentResultsWithContext(context.Context, SearchContentQuery) (*SearchPageResults, error)
+	PaginateContent(context.Context, ContentQuery) *ContentPaginator
+	PaginateSearch(context.Context, SearchContentQuery) *SearchPaginator
+	StreamSearchResults(context.Context, SearchContentQuery, int, func(SearchPageResult) error) error
+	PaginateLinks(context.Context, Links) *ContentPaginator
 }
 
 // api is the main api data structure
@@ -21,6 +99,10 @@ type api struct {
 	endPoint        *url.URL
 	client          *http.Client
 	username, token string
+
+	mu      sync.RWMutex // guards retry and limiter, which can be replaced while requests run
+	retry   *RetryPolicy
+	limiter *rateLimiter
 }
 
 type Content struct {
@@ -33,6 +115,8 @@ type Content struct {
 
 type Children struct {
 	Attachment Attachment `json:"attachment,omitempty"`
+	Page       Content    `json:"page,omitempty"`
+	Comment    Content    `json:"comment,omitempty"`
 }
 
 type Attachment struct {
@@ -43,44 +127,333 @@ type Attachment struct {
 	Links   Links     `json:"_links,omitempty"`
 }
 
+// Results is a content. The nested objects Confluence returns only when expanded are nil otherwise.
 type Results struct {
-	ID         string     `json:"id,omitempty"`
-	Type       string     `json:"type,omitempty"`
-	Status     string     `json:"status,omitempty"`
-	Title      string     `json:"title,omitempty"`
-	Children   Children   `json:"children,omitempty"`
-	Body       Body       `json:"body,omitempty"`
-	Expandable Expandable `json:"_expandable,omitempty"`
-	Links      Links      `json:"_links,omitempty"`
-	Metadata   Metadata   `json:"metadata,omitempty"`
+	ID           string        `json:"id,omitempty"`
+	Type         string        `json:"type,omitempty"`
+	Status       string        `json:"status,omitempty"`
+	Title        string        `json:"title,omitempty"`
+	Space        *Space        `json:"space,omitempty"`
+	History      *History      `json:"history,omitempty"`
+	Version      *Version      `json:"version,omitempty"`
+	Ancestors    []Results     `json:"ancestors,omitempty"`
+	Container    *Container    `json:"container,omitempty"`
+	Children     Children      `json:"children,omitempty"`
+	Body         *Body         `json:"body,omitempty"`
+	Extensions   *Extensions   `json:"extensions,omitempty"`
+	Restrictions *Restrictions `json:"restrictions,omitempty"`
+	Expandable   Expandable    `json:"_expandable,omitempty"`
+	Links        Links         `json:"_links,omitempty"`
+	Metadata     Metadata      `json:"metadata,omitempty"`
+}
+
+type Version struct {
+	By                  *User      `json:"by,omitempty"`
+	When                *time.Time `json:"when,omitempty"`
+	FriendlyWhen        string     `json:"friendlyWhen,omitempty"`
+	Number              int        `json:"number,omitempty"`
+	Message             string     `json:"message,omitempty"`
+	MinorEdit           bool       `json:"minorEdit,omitempty"`
+	ContentTypeModified bool       `json:"contentTypeModified,omitempty"`
+	Links               *Links     `json:"_links,omitempty"`
+}
+
+type User struct {
+	Type                   string         `json:"type,omitempty"` // known, unknown, anonymous, user
+	AccountID              string         `json:"accountId,omitempty"`
+	AccountType            string         `json:"accountType,omitempty"` // atlassian, app
+	Email                  string         `json:"email,omitempty"`
+	PublicName             string         `json:"publicName,omitempty"`
+	DisplayName            string         `json:"displayName,omitempty"`
+	ProfilePicture         ProfilePicture `json:"profilePicture,omitempty"`
+	IsExternalCollaborator bool           `json:"isExternalCollaborator,omitempty"`
+	Links                  Links          `json:"_links,omitempty"`
+}
+
+type ProfilePicture struct {
+	Path      string `json:"path,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	IsDefault bool   `json:"isDefault,omitempty"`
+}
+
+type Group struct {
+	Type  string `json:"type,omitempty"`
+	Name  string `json:"name,omitempty"`
+	ID    string `json:"id,omitempty"`
+	Links Links  `json:"_links,omitempty"`
+}
+
+type Space struct {
+	ID          int64            `json:"id,omitempty"`
+	Key         string           `json:"key,omitempty"`
+	Name        string           `json:"name,omitempty"`
+	Type        string           `json:"type,omitempty"`   // global, personal
+	Status      string           `json:"status,omitempty"` // current, archived
+	Icon        Icon             `json:"icon,omitempty"`
+	Description SpaceDescription `json:"description,omitempty"`
+	Homepage    *Results         `json:"homepage,omitempty"`
+	Metadata    SpaceMetadata    `json:"metadata,omitempty"`
+	Expandable  Expandable       `json:"_expandable,omitempty"`
+	Links       Links            `json:"_links,omitempty"`
+}
+
+// SpacePage is a page of spaces
+type SpacePage struct {
+	Results []Space `json:"results,omitempty"`
+	Start   int     `json:"start,omitempty"`
+	Limit   int     `json:"limit,omitempty"`
+	Size    int     `json:"size,omitempty"`
+	Links   Links   `json:"_links,omitempty"`
+}
+
+type Icon struct {
+	Path      string `json:"path,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	IsDefault bool   `json:"isDefault,omitempty"`
+}
+
+type SpaceDescription struct {
+	Plain ContentBody `json:"plain,omitempty"`
+	View  ContentBody `json:"view,omitempty"`
+}
+
+type SpaceMetadata struct {
+	Labels LabelArray `json:"labels,omitempty"`
+}
+
+type History struct {
+	Latest          bool          `json:"latest,omitempty"`
+	CreatedBy       *User         `json:"createdBy,omitempty"`
+	CreatedDate     *time.Time    `json:"createdDate,omitempty"`
+	LastUpdated     *Version      `json:"lastUpdated,omitempty"`
+	PreviousVersion *Version      `json:"previousVersion,omitempty"`
+	NextVersion     *Version      `json:"nextVersion,omitempty"`
+	Contributors    *Contributors `json:"contributors,omitempty"`
+	Expandable      Expandable    `json:"_expandable,omitempty"`
+	Links           Links         `json:"_links,omitempty"`
+}
+
+type Contributors struct {
+	Publishers Publishers `json:"publishers,omitempty"`
+}
+
+type Publishers struct {
+	Users    []User   `json:"users,omitempty"`
+	UserKeys []string `json:"userKeys,omitempty"`
+}
+
+// Container is the space or content holding a content,
+// e.g. the page an attachment or comment belongs to
+type Container struct {
+	ID     ID     `json:"id,omitempty"`
+	Key    string `json:"key,omitempty"` // set when the container is a space
+	Name   string `json:"name,omitempty"`
+	Type   string `json:"type,omitempty"`
+	Status string `json:"status,omitempty"`
+	Title  string `json:"title,omitempty"`
+	Links  Links  `json:"_links,omitempty"`
+}
+
+// ID is an identifier Confluence returns either as a string or, for spaces, as a number
+type ID string
+
+// UnmarshalJSON accepts both JSON strings and numbers
+func (id *ID) UnmarshalJSON(b []byte) error {
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err == nil {
+		*id = ID(n)
+		return nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	*id = ID(s)
+	return nil
+}
+
+// Extensions holds the type specific properties of a content
+type Extensions struct {
+	Position  Position `json:"position,omitempty"`  // page
+	MediaType string   `json:"mediaType,omitempty"` // attachment
+	FileSize  int64    `json:"fileSize,omitempty"`  // attachment
+	Comment   string   `json:"comment,omitempty"`   // attachment
+	FileID    string   `json:"fileId,omitempty"`    // attachment
+	Location  string   `json:"location,omitempty"`  // comment: footer, inline, resolved
+
+	InlineProperties *InlineProperties `json:"inlineProperties,omitempty"` // inline comment
+	Resolution       *Resolution       `json:"resolution,omitempty"`       // inline comment
+}
+
+// InlineProperties locates an inline comment in the text of its page
+type InlineProperties struct {
+	MarkerRef            string `json:"markerRef,omitempty"`            // id of the marker wrapping the selection in the page body
+	OriginalSelection    string `json:"originalSelection,omitempty"`    // selected text
+	SerializedHighlights string `json:"serializedHighlights,omitempty"` // used when creating the comment
+	MatchIndex           int    `json:"matchIndex"`                     // occurrence of the selection in the page text, from 0
+	NumMatches           int    `json:"numMatches,omitempty"`           // occurrences of the selection in the page text
+}
+
+// Resolution is the resolution state of an inline comment
+type Resolution struct {
+	Status           string `json:"status,omitempty"` // open, reopened, resolved, dangling
+	LastModifier     *User  `json:"lastModifier,omitempty"`
+	LastModifiedDate string `json:"lastModifiedDate,omitempty"`
+}
+
+// Position is the position of a page among its siblings, -1 when the page has none
+type Position int
+
+// UnmarshalJSON accepts both numbers and the "none" Confluence returns for unordered pages
+func (p *Position) UnmarshalJSON(b []byte) error {
+	var n int
+	if err := json.Unmarshal(b, &n); err != nil {
+		*p = -1
+		return nil
+	}
+	*p = Position(n)
+	return nil
+}
+
+type Restrictions struct {
+	Read   Restriction `json:"read,omitempty"`
+	Update Restriction `json:"update,omitempty"`
+	Links  Links       `json:"_links,omitempty"`
 }
 
+type Restriction struct {
+	Operation    string            `json:"operation,omitempty"` // read, update
+	Restrictions RestrictionGroups `json:"restrictions,omitempty"`
+	Links        Links             `json:"_links,omitempty"`
+}
+
+type RestrictionGroups struct {
+	User  UserArray  `json:"user,omitempty"`
+	Group GroupArray `json:"group,omitempty"`
+}
+
+type UserArray struct {
+	Results []User `json:"results,omitempty"`
+	Start   int    `json:"start,omitempty"`
+	Limit   int    `json:"limit,omitempty"`
+	Size    int    `json:"size,omitempty"`
+}
+
+type GroupArray struct {
+	Results []Group `json:"results,omitempty"`
+	Start   int     `json:"start,omitempty"`
+	Limit   int     `json:"limit,omitempty"`
+	Size    int     `json:"size,omitempty"`
+}
+
+// Storage is a body value in one of the representations of Body
 type Storage struct {
 	Value           string        `json:"value,omitempty"`
 	Representation  string        `json:"representation,omitempty"`
 	Embeddedcontent []interface{} `json:"embeddedContent,omitempty"`
+	Webresource     *WebResource  `json:"webresource,omitempty"`
+	MediaToken      *MediaToken   `json:"mediaToken,omitempty"`
 	Expandable      Expandable    `json:"_expandable,omitempty"`
 }
 
 type Body struct {
-	Storage Storage `json:"storage,omitempty"`
+	Storage             *Storage `json:"storage,omitempty"`
+	View                *Storage `json:"view,omitempty"`
+	ExportView          *Storage `json:"export_view,omitempty"`
+	StyledView          *Storage `json:"styled_view,omitempty"`
+	Editor              *Storage `json:"editor,omitempty"`
+	Editor2             *Storage `json:"editor2,omitempty"`
+	AnonymousExportView *Storage `json:"anonymous_export_view,omitempty"`
+	AtlasDocFormat      *Storage `json:"atlas_doc_format,omitempty"`
+	Wiki                *Storage `json:"wiki,omitempty"`
+	Dynamic             *Storage `json:"dynamic,omitempty"`
+}
+
+// WebResource lists the css and javascript needed to display a rendered body, e.g. styled_view
+type WebResource struct {
+	Keys       []string        `json:"keys,omitempty"`
+	Contexts   []string        `json:"contexts,omitempty"`
+	Uris       WebResourceURIs `json:"uris,omitempty"`
+	Tags       WebResourceTags `json:"tags,omitempty"`
+	Superbatch SuperBatch      `json:"superbatch,omitempty"`
+}
+
+type WebResourceURIs struct {
+	All []string `json:"all,omitempty"`
+	CSS []string `json:"css,omitempty"`
+	JS  []string `json:"js,omitempty"`
+}
+
+type WebResourceTags struct {
+	All  string `json:"all,omitempty"`
+	CSS  string `json:"css,omitempty"`
+	Data string `json:"data,omitempty"`
+	JS   string `json:"js,omitempty"`
+}
+
+type SuperBatch struct {
+	Uris     WebResourceURIs `json:"uris,omitempty"`
+	Tags     WebResourceTags `json:"tags,omitempty"`
+	Metatags string          `json:"metatags,omitempty"`
+}
+
+type MediaToken struct {
+	CollectionIds  []string `json:"collectionIds,omitempty"`
+	ContentID      string   `json:"contentId,omitempty"`
+	ExpiryDateTime string   `json:"expiryDateTime,omitempty"`
+	FileIds        []string `json:"fileIds,omitempty"`
+	Token          string   `json:"token,omitempty"`
 }
 
 type Metadata struct {
-	MediaType string `json:"mediaType,omitempty"`
+	MediaType string      `json:"mediaType,omitempty"`
+	Comment   string      `json:"comment,omitempty"`
+	Labels    *LabelArray `json:"labels,omitempty"`
+}
+
+type Label struct {
+	Prefix string `json:"prefix,omitempty"` // global, my, team
+	Name   string `json:"name,omitempty"`
+	ID     string `json:"id,omitempty"`
+	Label  string `json:"label,omitempty"`
+}
+
+type LabelArray struct {
+	Results []Label `json:"results,omitempty"`
+	Start   int     `json:"start,omitempty"`
+	Limit   int     `json:"limit,omitempty"`
+	Size    int     `json:"size,omitempty"`
+	Links   Links   `json:"_links,omitempty"`
 }
 
 type Expandable struct {
-	Space string `json:"space,omitempty"`
+	Space        string `json:"space,omitempty"`
+	Container    string `json:"container,omitempty"`
+	Metadata     string `json:"metadata,omitempty"`
+	Operations   string `json:"operations,omitempty"`
+	Children     string `json:"children,omitempty"`
+	Restrictions string `json:"restrictions,omitempty"`
+	History      string `json:"history,omitempty"`
+	Ancestors    string `json:"ancestors,omitempty"`
+	Body         string `json:"body,omitempty"`
+	Version      string `json:"version,omitempty"`
+	Descendants  string `json:"descendants,omitempty"`
 }
 
 type Links struct {
-	Base     string `json:"base,omitempty"`
-	Self     string `json:"self,omitempty"`
-	Next     string `json:"next,omitempty"`
-	Tinyui   string `json:"tinyui,omitempty"`
-	Webui    string `json:"webui,omitempty"`
-	Download string `json:"download,omitempty"`
+	Base       string `json:"base,omitempty"`
+	Context    string `json:"context,omitempty"`
+	Self       string `json:"self,omitempty"`
+	Next       string `json:"next,omitempty"`
+	Prev       string `json:"prev,omitempty"`
+	Tinyui     string `json:"tinyui,omitempty"`
+	Editui     string `json:"editui,omitempty"`
+	Webui      string `json:"webui,omitempty"`
+	Download   string `json:"download,omitempty"`
+	Collection string `json:"collection,omitempty"`
+	Status     string `json:"status,omitempty"`
 }
 
 // ContentQuery defines the query parameters
@@ -100,10 +473,412 @@ type ContentQuery struct {
 	Version    int    //version number when not lastest
 }
 
+// ContentByIDQuery defines the query parameters
+// used for getting a single content
+// Query parameter values https://developer.atlassian.com/cloud/confluence/rest/api-group-content/#api-wiki-rest-api-content-id-get
+type ContentByIDQuery struct {
+	Expand                []string
+	Status                []string // current, trashed, draft, historical, any
+	Version               int      // version number when not latest
+	EmbeddedContentRender string   // current, version-at-save
+	Trigger               string   // viewed
+}
+
+// Body representations, only storage, atlas_doc_format and wiki
+// are accepted when creating or updating content
+const (
+	RepresentationStorage             = "storage"
+	RepresentationAtlasDocFormat      = "atlas_doc_format"
+	RepresentationWiki                = "wiki"
+	RepresentationView                = "view"
+	RepresentationExportView          = "export_view"
+	RepresentationStyledView          = "styled_view"
+	RepresentationEditor              = "editor"
+	RepresentationEditor2             = "editor2"
+	RepresentationAnonymousExportView = "anonymous_export_view"
+	RepresentationDynamic             = "dynamic"
+)
+
+// ContentBody is a body value in a given representation
+type ContentBody struct {
+	Value          string `json:"value"`
+	Representation string `json:"representation"`
+}
+
+// CreateContentRequest defines the page or blog post to create
+type CreateContentRequest struct {
+	Type     string // page, blogpost
+	Title    string
+	SpaceKey string
+	ParentID string      // id of the parent page, optional
+	Body     ContentBody // storage, atlas_doc_format or wiki, defaults to storage
+	Status   string      // current, draft
+}
+
+// contentPayload is the content body expected by POST and PUT /content
+type contentPayload struct {
+	ID        string                 `json:"id,omitempty"`
+	Type      string                 `json:"type"`
+	Title     string                 `json:"title,omitempty"`
+	Status    string                 `json:"status,omitempty"`
+	Space     *spacePayload          `json:"space,omitempty"`
+	Ancestors []idPayload            `json:"ancestors,omitempty"`
+	Body      map[string]ContentBody `json:"body,omitempty"`
+	Version   *versionPayload        `json:"version,omitempty"`
+
+	Container  *containerPayload         `json:"container,omitempty"`  // comment
+	Extensions *commentExtensionsPayload `json:"extensions,omitempty"` // comment
+}
+
+type commentExtensionsPayload struct {
+	Location         string             `json:"location,omitempty"`
+	InlineProperties *InlineProperties  `json:"inlineProperties,omitempty"`
+	Resolution       *resolutionPayload `json:"resolution,omitempty"`
+}
+
+type resolutionPayload struct {
+	Status string `json:"status"`
+}
+
+type versionPayload struct {
+	Number    int    `json:"number"`
+	Message   string `json:"message,omitempty"`
+	MinorEdit bool   `json:"minorEdit,omitempty"`
+}
+
+type spacePayload struct {
+	Key string `json:"key"`
+}
+
+type idPayload struct {
+	ID string `json:"id"`
+}
+
+// UpdateContentOptions defines how content is updated
+type UpdateContentOptions struct {
+	VersionMessage string
+	MinorEdit      bool   // do not notify watchers
+	Representation string // body representation saved, required when the body holds several; storage for UpdateContentFunc when empty
+}
+
+// UpdateContentFuncOptions defines how content is updated by UpdateContentFunc
+type UpdateContentFuncOptions struct {
+	UpdateContentOptions
+	ConflictRetries int // times the content is read and the mutation applied again on a conflict
+}
+
+// UpdatePropertyOptions defines how a content property is updated
+type UpdatePropertyOptions struct {
+	MinorEdit bool // do not notify watchers
+}
+
+// UpdatePropertyFuncOptions defines how a content property is updated by SetContentPropertyValue and UpdateContentPropertyFunc
+type UpdatePropertyFuncOptions struct {
+	UpdatePropertyOptions
+	ConflictRetries int // times the property is read and the value computed again on a conflict
+}
+
+// ConvertBodyQuery defines the query parameters
+// used for converting a body between representations
+// Query parameter values https://developer.atlassian.com/cloud/confluence/rest/api-group-content-body/#api-wiki-rest-api-contentbody-convert-to-post
+type ConvertBodyQuery struct {
+	ContentIDContext      string   // content used to render macros, e.g. the page a body belongs to
+	SpaceKeyContext       string   // space used to render macros
+	EmbeddedContentRender string   // current, version-at-save
+	Expand                []string // e.g. webresource.tags.all, embeddedContent, mediaToken
+}
+
+// AsyncConvertedBody is the state of an asynchronous body conversion
+type AsyncConvertedBody struct {
+	Value           string        `json:"value,omitempty"`
+	Representation  string        `json:"representation,omitempty"`
+	RenderTaskID    string        `json:"renderTaskId,omitempty"`
+	Status          string        `json:"status,omitempty"` // WORKING, QUEUED, FAILED, COMPLETED, RERUNNING
+	Error           string        `json:"error,omitempty"`
+	Embeddedcontent []interface{} `json:"embeddedContent,omitempty"`
+	Webresource     *WebResource  `json:"webresource,omitempty"`
+	MediaToken      *MediaToken   `json:"mediaToken,omitempty"`
+}
+
+// UploadAttachmentOptions defines how an attachment is uploaded
+type UploadAttachmentOptions struct {
+	Comment     string
+	MinorEdit   bool   // do not notify watchers
+	ContentType string // media type of the file, application/octet-stream when empty
+	NewVersion  bool   // add a new version when an attachment with the same name exists instead of failing
+}
+
+// DownloadAttachmentOptions defines how an attachment is downloaded
+type DownloadAttachmentOptions struct {
+	Offset     int64  // resume the download from this byte using an HTTP Range request
+	VerifySize bool   // check the downloaded size against the attachment extensions.fileSize, which must have been read
+	SHA256     string // hex encoded checksum verified by DownloadAttachmentTo
+}
+
+// AttachmentQuery defines the query parameters
+// used for listing the attachments of a content
+type AttachmentQuery struct {
+	Expand    []string // version and container when empty
+	Filename  string
+	MediaType string
+	Limit     int // page limit
+}
+
+// FileAttachment is an attachment with its file properties
+type FileAttachment struct {
+	ID        string
+	Title     string // file name
+	Status    string
+	MediaType string
+	FileSize  int64
+	Comment   string
+	FileID    string
+	Version   Version
+	Container Container // content the file is attached to
+	Links     Links
+}
+
+type attachmentPayload struct {
+	ID        string                    `json:"id"`
+	Type      string                    `json:"type"`
+	Title     string                    `json:"title,omitempty"`
+	Version   versionPayload            `json:"version"`
+	Metadata  attachmentMetadataPayload `json:"metadata"`
+	Container *containerPayload         `json:"container,omitempty"`
+}
+
+type attachmentMetadataPayload struct {
+	MediaType string `json:"mediaType,omitempty"`
+	Comment   string `json:"comment,omitempty"`
+}
+
+// ContentProperty is a JSON value stored on a content under a key
+type ContentProperty struct {
+	ID      string          `json:"id,omitempty"`
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version Version         `json:"version,omitempty"`
+	Links   Links           `json:"_links,omitempty"`
+}
+
+type propertyPayload struct {
+	Key     string          `json:"key"`
+	Value   json.RawMessage `json:"value"`
+	Version *versionPayload `json:"version,omitempty"`
+}
+
+// SpaceQuery defines the query parameters
+// used for listing spaces
+// Query parameter values https://developer.atlassian.com/cloud/confluence/rest/api-group-space/#api-wiki-rest-api-space-get
+type SpaceQuery struct {
+	SpaceKey  []string
+	Type      string // global, personal
+	Status    string // current, archived
+	Label     []string
+	Favourite bool // only the spaces the current user marked as favourite
+	Expand    []string
+	Start     int // page start
+	Limit     int // page limit
+}
+
+// CreateSpaceRequest defines the space to create.
+// Confluence Cloud has no REST call creating personal spaces, those are created by their owner in the UI.
+type CreateSpaceRequest struct {
+	Key         string
+	Name        string
+	Description string // plain text description
+	Private     bool   // site space only visible to its creator, its type stays global
+}
+
+// UpdateSpaceRequest defines the space properties to update, empty values are left unchanged
+type UpdateSpaceRequest struct {
+	Name        string
+	Description string // plain text description
+	HomepageID  string
+}
+
+// Depths of a space content listing
+const (
+	DepthRoot = "root" // only the top level content of the space
+	DepthAll  = "all"  // all content of the space
+)
+
+// SpaceContentQuery defines the query parameters
+// used for listing the content of a space
+// Query parameter values https://developer.atlassian.com/cloud/confluence/rest/api-group-space/#api-wiki-rest-api-space-spacekey-content-type-get
+type SpaceContentQuery struct {
+	Depth  string // root, all
+	Expand []string
+	Start  int // page start
+	Limit  int // page limit
+}
+
+// SpaceRoots holds the homepage and the root pages of a space
+type SpaceRoots struct {
+	Homepage *Results // nil when the space has no homepage
+	Pages    []Results
+}
+
+// MovePageOptions configures MovePage
+type MovePageOptions struct {
+	UpdateAncestors bool // move by updating the ancestors of the page instead of using the move endpoint
+}
+
+// MoveSubtreeOptions configures MoveSubtreeToSpace
+type MoveSubtreeOptions struct {
+	ParentID         string // page of the target space the subtree is appended to, its homepage when empty
+	AllowBrokenLinks bool   // move even when links of the moved pages would break
+	DryRun           bool   // only run the checks
+	UpdateAncestors  bool   // move by updating the ancestors of the subtree root instead of using the move endpoint
+}
+
+// MoveCheck is the outcome of the checks run before moving a subtree to another space
+type MoveCheck struct {
+	Pages           []Results    // the moved pages, the subtree root first
+	TitleCollisions []Results    // pages of the target space sharing a title with a moved page
+	BrokenLinks     []BrokenLink // links which would resolve to another page after the move
+}
+
+// BrokenLink is a link of a moved page to a page of its space by title only.
+// After the move the link would be resolved in the target space.
+type BrokenLink struct {
+	PageID string
+	Title  string // title of the linked page
+}
+
+// CopyPageOptions defines the copy of a single page
+type CopyPageOptions struct {
+	DestinationType string // CopyToSpace, CopyToParentPage or CopyToExistingPage
+	Destination     string // space key or page id
+	Title           string // title of the copy, the title of the page when empty
+	TitlePrefix     string // added to the title when it is already used in the destination space
+	TitleSuffix     string // added to the title when it is already used in the destination space
+	CopyAttachments bool
+	CopyLabels      bool
+	CopyProperties  bool
+	CopyPermissions bool
+	Expand          []string // expansions of the returned copy
+}
+
+// CopyHierarchyOptions defines the copy of a page and its descendants
+type CopyHierarchyOptions struct {
+	DestinationPageID string // parent of the copied root page
+	TitlePrefix       string // added to every copied title
+	TitleSearch       string // replaced by TitleReplace in every copied title
+	TitleReplace      string
+	CopyAttachments   bool
+	CopyLabels        bool
+	CopyProperties    bool
+	CopyPermissions   bool
+}
+
+type copyPagePayload struct {
+	CopyAttachments bool                   `json:"copyAttachments"`
+	CopyPermissions bool                   `json:"copyPermissions"`
+	CopyProperties  bool                   `json:"copyProperties"`
+	CopyLabels      bool                   `json:"copyLabels"`
+	Destination     copyDestinationPayload `json:"destination"`
+	PageTitle       string                 `json:"pageTitle,omitempty"`
+}
+
+type copyDestinationPayload struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
+}
+
+type copyHierarchyPayload struct {
+	CopyAttachments   bool                    `json:"copyAttachments"`
+	CopyPermissions   bool                    `json:"copyPermissions"`
+	CopyProperties    bool                    `json:"copyProperties"`
+	CopyLabels        bool                    `json:"copyLabels"`
+	CopyDescendants   bool                    `json:"copyDescendants"`
+	DestinationPageID string                  `json:"destinationPageId"`
+	TitleOptions      copyTitleOptionsPayload `json:"titleOptions"`
+}
+
+type copyTitleOptionsPayload struct {
+	Prefix  string `json:"prefix,omitempty"`
+	Search  string `json:"search,omitempty"`
+	Replace string `json:"replace,omitempty"`
+}
+
+// CommentQuery defines the query parameters
+// used for listing the comments of a content
+// Query parameter values https://developer.atlassian.com/cloud/confluence/rest/api-group-content-child-and-descendants/#api-wiki-rest-api-content-id-child-comment-get
+type CommentQuery struct {
+	Location      []string // footer, inline, resolved; all comments when empty
+	Expand        []string // added to the expansions needed to thread the comments
+	ParentVersion int      // version of the content the comments were made on, the latest when 0
+}
+
+// CreateCommentRequest defines the comment to create
+type CreateCommentRequest struct {
+	Body          ContentBody
+	ContainerType string            // page or blogpost, page when empty
+	ParentID      string            // comment replied to, a top level comment when empty
+	Inline        *InlineProperties // selection of an inline comment, a footer comment when nil
+}
+
+// CommentNode is a comment in a thread
+type CommentNode struct {
+	Comment Results
+	Parent  *CommentNode // nil for the top level comments
+	Replies []*CommentNode
+}
+
+// LongTaskRef references a long running task started by an operation
+type LongTaskRef struct {
+	ID    string `json:"id"`
+	Links Links  `json:"links,omitempty"`
+}
+
+// LongTask is the state of a long running task
+type LongTask struct {
+	ID                 string            `json:"id"`
+	Name               LongTaskMessage   `json:"name,omitempty"`
+	ElapsedTime        int64             `json:"elapsedTime,omitempty"` // milliseconds
+	PercentageComplete int               `json:"percentageComplete"`
+	Successful         bool              `json:"successful"`
+	Finished           bool              `json:"finished"`
+	Status             string            `json:"status,omitempty"`
+	Messages           []LongTaskMessage `json:"messages,omitempty"`
+	Errors             []LongTaskMessage `json:"errors,omitempty"`
+	Links              Links             `json:"_links,omitempty"`
+}
+
+// LongTaskMessage is a message of a long running task
+type LongTaskMessage struct {
+	Key         string        `json:"key,omitempty"`
+	Translation string        `json:"translation,omitempty"`
+	Args        []interface{} `json:"args,omitempty"`
+}
+
+type spaceRequestPayload struct {
+	Key         string                   `json:"key,omitempty"`
+	Name        string                   `json:"name,omitempty"`
+	Status      string                   `json:"status,omitempty"`
+	Description *spaceDescriptionPayload `json:"description,omitempty"`
+	Homepage    *idPayload               `json:"homepage,omitempty"`
+}
+
+type spaceDescriptionPayload struct {
+	Plain ContentBody `json:"plain"`
+}
+
+type labelPayload struct {
+	Prefix string `json:"prefix"`
+	Name   string `json:"name"`
+}
+
+type containerPayload struct {
+	ID   string `json:"id"`
+	Type string `json:"type"`
+}
+
 type SearchContentQuery struct {
 	Cql                   string
 	CqlContext            map[string]string // The space, content, and content status to execute the search against: spaceKey, contentId, contentStatuses
-	cursor                string            // Pointer to a set of search results, returned as part of the next or prev URL from the previous search call.
+	Cursor                string            // Pointer to a set of search results, returned as part of the next or prev URL from the previous search call.
 	Limit                 int               // The maximum number of content objects to return per page. Note, this may be restricted by fixed system limits.
 	IncludeArchivedSpaces bool              // Include content from archived spaces in the results.
 }
@@ -120,7 +895,7 @@ type SearchPageResults struct {
 }
 
 type SearchPageResult struct {
-	Content               Content          `json:"content"`
+	Content               Results          `json:"content"` // the content found, empty for space and user hits
 	Title                 string           `json:"title"`
 	Excerpt               string           `json:"excerpt"`
 	Url                   string           `json:"url"`
@@ -0,0 +1,121 @@
+package confluentcloud
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// loadFixture unmarshals testdata/name into v
+func loadFixture(t *testing.T, name string, v interface{}) []byte {
+	b, err := ioutil.ReadFile("testdata/" + name)
+	assert.Nil(t, err)
+	assert.Nil(t, json.Unmarshal(b, v))
+	return b
+}
+
+// assertRoundTrip checks that marshalling and unmarshalling c again does not lose data
+func assertRoundTrip(t *testing.T, c Results) {
+	b, err := json.Marshal(c)
+	assert.Nil(t, err)
+	var again Results
+	assert.Nil(t, json.Unmarshal(b, &again))
+	b2, err := json.Marshal(again)
+	assert.Nil(t, err)
+	assert.JSONEq(t, string(b), string(b2))
+	assert.Equal(t, c.Version, again.Version)
+	assert.Equal(t, c.History, again.History)
+	assert.Equal(t, c.Ancestors, again.Ancestors)
+	assert.Equal(t, c.Container, again.Container)
+	assert.Equal(t, c.Extensions, again.Extensions)
+}
+
+func TestResults_PageFixture(t *testing.T) {
+	var c Results
+	loadFixture(t, "page.json", &c)
+
+	assert.Equal(t, "65538", c.ID)
+	assert.Equal(t, "Onboarding", c.Title)
+
+	assert.Equal(t, int64(32770), c.Space.ID)
+	assert.Equal(t, "DOC", c.Space.Key)
+	assert.Equal(t, "global", c.Space.Type)
+
+	assert.True(t, c.History.Latest)
+	assert.Equal(t, "Mia Krystof", c.History.CreatedBy.DisplayName)
+	assert.Equal(t, time.Date(2021, 3, 2, 9, 15, 43, 0, time.UTC), *c.History.CreatedDate)
+	assert.Equal(t, 7, c.History.LastUpdated.Number)
+	assert.Len(t, c.History.Contributors.Publishers.Users, 2)
+	assert.Len(t, c.History.Contributors.Publishers.UserKeys, 2)
+
+	assert.Equal(t, 7, c.Version.Number)
+	assert.Equal(t, "Add VPN section", c.Version.Message)
+	assert.Equal(t, "5b10ac8d82e05b22cc7d4ef5", c.Version.By.AccountID)
+	assert.Equal(t, time.Date(2021, 4, 12, 14, 2, 11, 512000000, time.UTC), *c.Version.When)
+
+	assert.Len(t, c.Ancestors, 2)
+	assert.Equal(t, "65537", c.Ancestors[0].ID)
+	assert.Equal(t, Position(-1), c.Ancestors[0].Extensions.Position)
+	assert.Equal(t, Position(2), c.Ancestors[1].Extensions.Position)
+	assert.Equal(t, Position(4), c.Extensions.Position)
+
+	assert.Equal(t, ID("32770"), c.Container.ID)
+	assert.Equal(t, "DOC", c.Container.Key)
+
+	assert.Equal(t, "read", c.Restrictions.Read.Operation)
+	assert.Equal(t, "confluence-users", c.Restrictions.Read.Restrictions.Group.Results[0].Name)
+	assert.Equal(t, 1, c.Restrictions.Read.Restrictions.User.Size)
+	assert.Empty(t, c.Restrictions.Update.Restrictions.User.Results)
+
+	assert.Equal(t, []Label{
+		{Prefix: "global", Name: "onboarding", ID: "98306", Label: "onboarding"},
+		{Prefix: "global", Name: "hr", ID: "98307", Label: "hr"},
+	}, c.Metadata.Labels.Results)
+
+	assert.Equal(t, "/rest/api/content/65538/descendant", c.Expandable.Descendants)
+	assert.Equal(t, "/pages/resumedraft.action?draftId=65538", c.Links.Editui)
+	assert.Equal(t, "https://example.atlassian.net/wiki", c.Links.Base)
+
+	assertRoundTrip(t, c)
+}
+
+func TestResults_AttachmentFixture(t *testing.T) {
+	var c Results
+	loadFixture(t, "attachment.json", &c)
+
+	assert.Equal(t, "att98310", c.ID)
+	assert.Equal(t, "attachment", c.Type)
+	assert.Equal(t, ID("65538"), c.Container.ID)
+	assert.Equal(t, "image/png", c.Metadata.MediaType)
+	assert.Equal(t, "Updated diagram", c.Metadata.Comment)
+	assert.Equal(t, int64(48213), c.Extensions.FileSize)
+	assert.Equal(t, "1f4c3b7e-6d2a-4a4f-9b1e-2c3d4e5f6a7b", c.Extensions.FileID)
+	assert.True(t, c.Version.MinorEdit)
+
+	assertRoundTrip(t, c)
+}
+
+func TestResults_MarshalOmitsUnread(t *testing.T) {
+	b, err := json.Marshal(Content{Results: []Results{{ID: "1"}}})
+	assert.Nil(t, err)
+	var c struct {
+		Results []map[string]json.RawMessage `json:"results"`
+	}
+	assert.Nil(t, json.Unmarshal(b, &c))
+	for _, key := range []string{"space", "history", "version", "container", "body", "extensions", "restrictions"} {
+		assert.NotContains(t, c.Results[0], key)
+	}
+	assert.NotContains(t, string(c.Results[0]["metadata"]), "labels")
+}
+
+func TestID_UnmarshalJSON(t *testing.T) {
+	var id ID
+	assert.Nil(t, json.Unmarshal([]byte(`123`), &id))
+	assert.Equal(t, ID("123"), id)
+	assert.Nil(t, json.Unmarshal([]byte(`"att1"`), &id))
+	assert.Equal(t, ID("att1"), id)
+	assert.NotNil(t, json.Unmarshal([]byte(`{}`), &id))
+}
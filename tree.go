@@ -0,0 +1,272 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// Control values returned by a WalkFunc
+var (
+	// SkipSubtree skips the children of the visited page
+	SkipSubtree = errors.New("skip this subtree")
+	// StopWalk ends the walk, WalkTree then returns the tree built so far without error
+	StopWalk = errors.New("stop the walk")
+)
+
+// WalkOrder is the order pages are visited by WalkTree
+type WalkOrder int
+
+// Walk orders
+const (
+	BreadthFirst WalkOrder = iota
+	DepthFirst
+)
+
+// WalkOptions configures WalkTree
+type WalkOptions struct {
+	Order       WalkOrder
+	Concurrency int // maximum number of child listings fetched at once, defaults to 4
+	MaxDepth    int // deepest level visited, the root being 0; 0 means unlimited
+	Expand      []string
+}
+
+// WalkFunc is called for every page of the tree, parents before their children.
+// Returning SkipSubtree skips the children of the page, StopWalk ends the walk
+// and any other error aborts it.
+type WalkFunc func(node *PageNode) error
+
+// PageNode is a page in the tree built by WalkTree
+type PageNode struct {
+	Page     Results
+	Parent   *PageNode // nil for the root
+	Children []*PageNode
+	Depth    int // distance to the root
+	Position int // index among its siblings, in the order Confluence sorts child pages
+}
+
+// getContentDescendantEndpoint creates the correct api endpoint by given id and type
+func (a *api) getContentDescendantEndpoint(id string, t string) (*url.URL, error) {
+	return url.ParseRequestURI(a.endPoint.String() + "/content/" + id + "/descendant/" + t)
+}
+
+// PaginateChildPages returns a paginator over the direct child pages of a page, sorted by position
+func (a *api) PaginateChildPages(ctx context.Context, id string, expand []string) *ContentPaginator {
+	ep, err := a.getContentChildEndpoint(id, "page")
+	return a.pageListing(ctx, ep, err, expand)
+}
+
+// PaginateDescendantPages returns a paginator over all the pages below a page
+func (a *api) PaginateDescendantPages(ctx context.Context, id string, expand []string) *ContentPaginator {
+	ep, err := a.getContentDescendantEndpoint(id, "page")
+	return a.pageListing(ctx, ep, err, expand)
+}
+
+func (a *api) pageListing(ctx context.Context, ep *url.URL, err error, expand []string) *ContentPaginator {
+	if err != nil {
+		c := &ContentPaginator{}
+		c.p.err = err
+		return c
+	}
+	if len(expand) != 0 {
+		ep.RawQuery = url.Values{"expand": {strings.Join(expand, ",")}}.Encode()
+	}
+	return a.newContentPaginator(ctx, ep.String())
+}
+
+// GetAncestors gets the ancestors of a content, starting with the root of its tree
+func (a *api) GetAncestors(ctx context.Context, id string) ([]Results, error) {
+	c, err := a.GetContentByID(ctx, id, ContentByIDQuery{Expand: []string{"ancestors"}})
+	if err != nil {
+		return nil, err
+	}
+	return c.Ancestors, nil
+}
+
+// WalkTree walks the page tree below rootID, calling fn for every page, and returns
+// the tree of listed pages; when the walk ends early it may hold pages not visited yet.
+// Child listings are fetched concurrently, children of pages not yet visited may be
+// prefetched and are discarded when their parent is skipped. fn is never called concurrently.
+func (a *api) WalkTree(ctx context.Context, rootID string, fn WalkFunc, opts WalkOptions) (*PageNode, error) {
+	page, err := a.GetContentByID(ctx, rootID, ContentByIDQuery{Expand: opts.Expand})
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = 4
+	}
+	w := &treeWalker{a: a, ctx: ctx, fn: fn, opts: opts, sem: make(chan struct{}, concurrency)}
+
+	root := &PageNode{Page: *page}
+	if opts.Order == DepthFirst {
+		err = w.depthFirst(root, nil)
+	} else {
+		err = w.breadthFirst(root)
+	}
+	if errors.Is(err, StopWalk) {
+		err = nil
+	}
+	return root, err
+}
+
+// treeWalker holds the state of a WalkTree call
+type treeWalker struct {
+	a    *api
+	ctx  context.Context
+	fn   WalkFunc
+	opts WalkOptions
+	sem  chan struct{}
+}
+
+// childFetch is a child listing running in the background
+type childFetch struct {
+	done   chan struct{}
+	pages  []Results
+	err    error
+	cancel context.CancelFunc
+}
+
+// fetchChildren starts listing the children of node, bounded by the walker concurrency
+func (w *treeWalker) fetchChildren(node *PageNode) *childFetch {
+	ctx, cancel := context.WithCancel(w.ctx)
+	f := &childFetch{done: make(chan struct{}), cancel: cancel}
+	go func() {
+		defer close(f.done)
+		select {
+		case w.sem <- struct{}{}:
+		case <-ctx.Done():
+			f.err = ctx.Err()
+			return
+		}
+		defer func() { <-w.sem }()
+		f.pages, f.err = w.a.PaginateChildPages(ctx, node.Page.ID, w.opts.Expand).All(0)
+	}()
+	return f
+}
+
+// expands reports whether the children of node are walked
+func (w *treeWalker) expands(node *PageNode) bool {
+	return w.opts.MaxDepth <= 0 || node.Depth < w.opts.MaxDepth
+}
+
+// attach waits for f and adds the fetched pages as children of node
+func (w *treeWalker) attach(node *PageNode, f *childFetch) error {
+	<-f.done
+	f.cancel()
+	if f.err != nil {
+		return f.err
+	}
+	node.Children = make([]*PageNode, len(f.pages))
+	for i, p := range f.pages {
+		node.Children[i] = &PageNode{Page: p, Parent: node, Depth: node.Depth + 1, Position: i}
+	}
+	return nil
+}
+
+// visit calls fn and reports whether the children of node are walked
+func (w *treeWalker) visit(node *PageNode) (bool, error) {
+	err := w.fn(node)
+	if errors.Is(err, SkipSubtree) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return w.expands(node), nil
+}
+
+func (w *treeWalker) breadthFirst(root *PageNode) error {
+	level := []*PageNode{root}
+	for len(level) != 0 {
+		var parents []*PageNode
+		var fetches []*childFetch
+		for _, node := range level {
+			expand, err := w.visit(node)
+			if err != nil {
+				cancelFetches(fetches)
+				return err
+			}
+			if expand {
+				parents = append(parents, node)
+				fetches = append(fetches, w.fetchChildren(node))
+			}
+		}
+
+		level = nil
+		for i, node := range parents {
+			if err := w.attach(node, fetches[i]); err != nil {
+				cancelFetches(fetches[i+1:])
+				return err
+			}
+			level = append(level, node.Children...)
+		}
+	}
+	return nil
+}
+
+// depthFirst visits node and its subtree, f is the prefetched child listing of node if any
+func (w *treeWalker) depthFirst(node *PageNode, f *childFetch) error {
+	expand, err := w.visit(node)
+	if !expand || err != nil {
+		if f != nil {
+			f.cancel()
+		}
+		return err
+	}
+
+	if f == nil {
+		f = w.fetchChildren(node)
+	}
+	if err := w.attach(node, f); err != nil {
+		return err
+	}
+
+	// prefetch the children listings of the next siblings while walking a subtree,
+	// at most the walker concurrency ahead so wide levels are not listed at once
+	fetches := make([]*childFetch, len(node.Children))
+	next := 0
+	for i, child := range node.Children {
+		for ; next < len(node.Children) && next < i+cap(w.sem); next++ {
+			if w.expands(node.Children[next]) {
+				fetches[next] = w.fetchChildren(node.Children[next])
+			}
+		}
+		if err := w.depthFirst(child, fetches[i]); err != nil {
+			cancelFetches(fetches[i+1 : next])
+			return err
+		}
+		fetches[i] = nil
+	}
+	return nil
+}
+
+func cancelFetches(fetches []*childFetch) {
+	for _, f := range fetches {
+		if f != nil {
+			f.cancel()
+		}
+	}
+}
+
+// Walk calls fn for n and all the nodes below it, parents before their children
+func (n *PageNode) Walk(fn func(*PageNode)) {
+	fn(n)
+	for _, c := range n.Children {
+		c.Walk(fn)
+	}
+}
+
+// Path returns the nodes from the root of the tree down to n
+func (n *PageNode) Path() []*PageNode {
+	var path []*PageNode
+	for p := n; p != nil; p = p.Parent {
+		path = append([]*PageNode{p}, path...)
+	}
+	return path
+}
@@ -0,0 +1,243 @@
+package confluentcloud
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// treeServer serves the page tree
+//
+//	1
+//	├── 2
+//	│   ├── 4
+//	│   └── 5
+//	└── 3
+//	    └── 6
+func treeServer(t *testing.T, inFlight *int32, maxInFlight *int32) *httptest.Server {
+	children := map[string][]string{"1": {"2", "3"}, "2": {"4", "5"}, "3": {"6"}}
+	page := func(id string) string {
+		return `{"id":"` + id + `","type":"page","title":"Page ` + id + `"}`
+	}
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/wiki/rest/api/content/")
+		parts := strings.Split(path, "/")
+		switch {
+		case len(parts) == 1 && r.URL.Query().Get("expand") == "ancestors":
+			w.Write([]byte(`{"id":"` + parts[0] + `","ancestors":[` + page("1") + `,` + page("2") + `]}`))
+		case len(parts) == 1 && parts[0] != "404":
+			w.Write([]byte(page(parts[0])))
+		case len(parts) == 3 && parts[1] == "child" && parts[2] == "page":
+			if parts[0] == "5" {
+				http.Error(w, `{"statusCode":500}`, http.StatusInternalServerError)
+				return
+			}
+			if inFlight != nil {
+				n := atomic.AddInt32(inFlight, 1)
+				for {
+					m := atomic.LoadInt32(maxInFlight)
+					if n <= m || atomic.CompareAndSwapInt32(maxInFlight, m, n) {
+						break
+					}
+				}
+				time.Sleep(10 * time.Millisecond)
+				defer atomic.AddInt32(inFlight, -1)
+			}
+			var results []string
+			for _, id := range children[parts[0]] {
+				results = append(results, page(id))
+			}
+			w.Write([]byte(`{"results":[` + strings.Join(results, ",") + `]}`))
+		case len(parts) == 3 && parts[1] == "descendant" && parts[2] == "page":
+			assert.Equal(t, "version", r.URL.Query().Get("expand"))
+			w.Write([]byte(`{"results":[` + page("2") + `,` + page("3") + `,` + page("4") + `]}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+}
+
+func Test_ChildDescendantAncestors(t *testing.T) {
+	server := treeServer(t, nil, nil)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	children, err := api.PaginateChildPages(ctx, "1", nil).All(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2", "3"}, resultIDs(children))
+
+	descendants, err := api.PaginateDescendantPages(ctx, "1", []string{"version"}).All(0)
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2", "3", "4"}, resultIDs(descendants))
+
+	ancestors, err := api.GetAncestors(ctx, "4")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2"}, resultIDs(ancestors))
+}
+
+func Test_WalkTree(t *testing.T) {
+	server := treeServer(t, nil, nil)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+	ctx := context.Background()
+
+	walk := func(opts WalkOptions, fn func(*PageNode) error) ([]string, *PageNode, error) {
+		var visited []string
+		root, err := api.WalkTree(ctx, "1", func(n *PageNode) error {
+			visited = append(visited, n.Page.ID)
+			return fn(n)
+		}, opts)
+		return visited, root, err
+	}
+	visited, root, err := walk(WalkOptions{}, skipPage("5"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "4", "5", "6"}, visited)
+	assert.Len(t, root.Children, 2)
+	four := root.Children[0].Children[0]
+	assert.Equal(t, "4", four.Page.ID)
+	assert.Equal(t, 2, four.Depth)
+	assert.Equal(t, 0, four.Position)
+	assert.Equal(t, 1, root.Children[1].Position)
+	assert.Equal(t, "2", four.Parent.Page.ID)
+	var path []string
+	for _, n := range four.Path() {
+		path = append(path, n.Page.ID)
+	}
+	assert.Equal(t, []string{"1", "2", "4"}, path)
+
+	visited, _, err = walk(WalkOptions{Order: DepthFirst}, skipPage("5"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "4", "5", "3", "6"}, visited)
+
+	visited, root, err = walk(WalkOptions{Order: DepthFirst}, skipPage("2"))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "3", "6"}, visited)
+	assert.Nil(t, root.Children[0].Children)
+
+	visited, _, err = walk(WalkOptions{MaxDepth: 1}, skipPage(""))
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "3"}, visited)
+
+	visited, root, err = walk(WalkOptions{Order: DepthFirst}, func(n *PageNode) error {
+		if n.Page.ID == "4" {
+			return StopWalk
+		}
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"1", "2", "4"}, visited)
+	assert.Len(t, root.Children, 2)
+
+	// 5 and 3 were listed before the walk stopped
+	var listed []string
+	root.Walk(func(n *PageNode) { listed = append(listed, n.Page.ID) })
+	assert.Equal(t, []string{"1", "2", "4", "5", "3"}, listed)
+
+	// listing the children of 5 fails
+	_, _, err = walk(WalkOptions{}, skipPage(""))
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+
+	boom := errors.New("boom")
+	visited, _, err = walk(WalkOptions{}, func(n *PageNode) error {
+		if n.Page.ID == "3" {
+			return boom
+		}
+		return nil
+	})
+	assert.Equal(t, boom, err)
+	assert.Equal(t, []string{"1", "2", "3"}, visited)
+
+	_, err = api.WalkTree(ctx, "404", func(*PageNode) error { return nil }, WalkOptions{})
+	assert.True(t, errors.Is(err, ErrNotFound))
+}
+
+func Test_WalkTreeConcurrency(t *testing.T) {
+	var inFlight, maxInFlight int32
+	server := treeServer(t, &inFlight, &maxInFlight)
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	_, err = api.WalkTree(context.Background(), "1", skipPage("5"), WalkOptions{Concurrency: 1})
+	assert.Nil(t, err)
+	assert.Equal(t, int32(1), maxInFlight)
+
+	atomic.StoreInt32(&maxInFlight, 0)
+	_, err = api.WalkTree(context.Background(), "1", skipPage("5"), WalkOptions{Concurrency: 3})
+	assert.Nil(t, err)
+	assert.True(t, maxInFlight > 1)
+}
+
+func Test_WalkTreeDepthFirstPrefetchWindow(t *testing.T) {
+	const width = 40
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path := strings.TrimPrefix(r.URL.Path, "/wiki/rest/api/content/")
+		switch {
+		case path == "root":
+			w.Write([]byte(`{"id":"root","type":"page"}`))
+		case path == "root/child/page":
+			var results []string
+			for i := 0; i < width; i++ {
+				results = append(results, `{"id":"`+strconv.Itoa(i)+`","type":"page"}`)
+			}
+			w.Write([]byte(`{"results":[` + strings.Join(results, ",") + `]}`))
+		case strings.HasSuffix(path, "/child/page"):
+			w.Write([]byte(`{"results":[]}`))
+		default:
+			http.Error(w, `{"statusCode":404}`, http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	api, err := NewAPI(server.URL+"/wiki/rest/api", "username", "token")
+	assert.Nil(t, err)
+
+	// the listings of a wide level are started a few at a time, not one goroutine per page
+	before := runtime.NumGoroutine()
+	peak := 0
+	visited := 0
+	_, err = api.WalkTree(context.Background(), "root", func(n *PageNode) error {
+		visited++
+		if n := runtime.NumGoroutine() - before; n > peak {
+			peak = n
+		}
+		return nil
+	}, WalkOptions{Order: DepthFirst, Concurrency: 2})
+	assert.Nil(t, err)
+	assert.Equal(t, width+1, visited)
+	assert.Less(t, peak, width/2)
+}
+
+func skipPage(id string) WalkFunc {
+	return func(n *PageNode) error {
+		if n.Page.ID == id {
+			return SkipSubtree
+		}
+		return nil
+	}
+}
+
+func resultIDs(results []Results) []string {
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, r.ID)
+	}
+	return ids
+}